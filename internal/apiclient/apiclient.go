@@ -0,0 +1,156 @@
+// Package apiclient implements the retry, rate-limit, and pagination
+// handling shared by the api example CLI's Do method, so every
+// subcommand (get/post/put/delete) gets the same resilience for free
+// instead of each reimplementing it around a bare *http.Client.
+package apiclient
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"net/http"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// Client wraps an *http.Client with retry and backoff policy.
+type Client struct {
+	HTTP           *http.Client
+	MaxRetries     int
+	RetryBaseDelay time.Duration
+}
+
+// Response is a fully-drained HTTP response: the body is read into memory
+// up front so callers (pagination, output formatting) can inspect it more
+// than once without worrying about a half-consumed io.ReadCloser.
+type Response struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+}
+
+// Do sends req, retrying on network errors and 5xx responses with
+// exponential backoff plus jitter, and honoring a 429 response's
+// Retry-After header instead of the backoff schedule. req.Body, if set,
+// must support GetBody for retries to be able to resend it.
+func (c *Client) Do(ctx context.Context, req *http.Request) (*Response, error) {
+	var lastErr error
+
+	for attempt := 0; attempt <= c.MaxRetries; attempt++ {
+		if attempt > 0 {
+			delay, err := c.retryDelay(attempt, lastErr)
+			if err != nil {
+				return nil, err
+			}
+			select {
+			case <-time.After(delay):
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		}
+
+		attemptReq := req.Clone(ctx)
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, fmt.Errorf("apiclient: rewinding request body: %w", err)
+			}
+			attemptReq.Body = body
+		}
+
+		httpResp, err := c.httpClient().Do(attemptReq)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+
+		body, err := io.ReadAll(httpResp.Body)
+		httpResp.Body.Close()
+		if err != nil {
+			lastErr = fmt.Errorf("apiclient: reading response body: %w", err)
+			continue
+		}
+
+		resp := &Response{StatusCode: httpResp.StatusCode, Header: httpResp.Header, Body: body}
+		if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500 {
+			lastErr = fmt.Errorf("apiclient: %s returned %d", req.URL, resp.StatusCode)
+			if attempt == c.MaxRetries {
+				return resp, lastErr
+			}
+			lastErr = retryableStatus{resp: resp, err: lastErr}
+			continue
+		}
+
+		return resp, nil
+	}
+
+	return nil, fmt.Errorf("apiclient: giving up after %d retries: %w", c.MaxRetries, lastErr)
+}
+
+// retryableStatus carries the triggering response alongside the error so
+// retryDelay can read a 429's Retry-After header without a second type
+// switch at the call site.
+type retryableStatus struct {
+	resp *Response
+	err  error
+}
+
+func (r retryableStatus) Error() string { return r.err.Error() }
+
+// retryDelay picks how long to wait before attempt, honoring Retry-After
+// on a 429 and otherwise backing off exponentially from RetryBaseDelay
+// with up to 50% jitter.
+func (c *Client) retryDelay(attempt int, lastErr error) (time.Duration, error) {
+	if status, ok := lastErr.(retryableStatus); ok && status.resp.StatusCode == http.StatusTooManyRequests {
+		if d, ok := parseRetryAfter(status.resp.Header.Get("Retry-After")); ok {
+			return d, nil
+		}
+	}
+
+	base := c.RetryBaseDelay
+	if base <= 0 {
+		base = 250 * time.Millisecond
+	}
+	backoff := time.Duration(float64(base) * math.Pow(2, float64(attempt-1)))
+	jitter := time.Duration(rand.Int63n(int64(backoff)/2 + 1))
+	return backoff + jitter, nil
+}
+
+func (c *Client) httpClient() *http.Client {
+	if c.HTTP != nil {
+		return c.HTTP
+	}
+	return http.DefaultClient
+}
+
+// parseRetryAfter accepts either form Retry-After may take: an integer
+// number of seconds, or an HTTP-date.
+func parseRetryAfter(v string) (time.Duration, bool) {
+	if v == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		return time.Duration(secs) * time.Second, true
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		return time.Until(t), true
+	}
+	return 0, false
+}
+
+var linkNextRE = regexp.MustCompile(`<([^>]+)>\s*;\s*rel="?next"?`)
+
+// NextPageURL extracts the RFC 5988 rel="next" target from a Link header,
+// as set by most paginated REST APIs (GitHub, Stripe, ...). It reports
+// false if the response has no next page.
+func NextPageURL(header http.Header) (string, bool) {
+	for _, link := range header.Values("Link") {
+		if m := linkNextRE.FindStringSubmatch(link); m != nil {
+			return m[1], true
+		}
+	}
+	return "", false
+}
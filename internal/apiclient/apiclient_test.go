@@ -0,0 +1,191 @@
+package apiclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestDoRetriesOn5xxThenSucceeds(t *testing.T) {
+	var attempts int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("ok"))
+	}))
+	defer srv.Close()
+
+	c := &Client{MaxRetries: 3, RetryBaseDelay: time.Millisecond}
+	req, err := http.NewRequest(http.MethodGet, srv.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := c.Do(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Do: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("StatusCode = %d, want 200", resp.StatusCode)
+	}
+	if got := atomic.LoadInt32(&attempts); got != 3 {
+		t.Fatalf("attempts = %d, want 3", got)
+	}
+}
+
+func TestDoGivesUpAfterMaxRetries(t *testing.T) {
+	var attempts int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	c := &Client{MaxRetries: 2, RetryBaseDelay: time.Millisecond}
+	req, err := http.NewRequest(http.MethodGet, srv.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = c.Do(context.Background(), req)
+	if err == nil {
+		t.Fatal("Do: want error, got nil")
+	}
+	if got := atomic.LoadInt32(&attempts); got != 3 {
+		t.Fatalf("attempts = %d, want 3 (1 + MaxRetries)", got)
+	}
+}
+
+func TestDoHonorsRetryAfterOn429(t *testing.T) {
+	var attempts int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	c := &Client{MaxRetries: 1, RetryBaseDelay: time.Hour}
+	req, err := http.NewRequest(http.MethodGet, srv.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Now()
+	resp, err := c.Do(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Do: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("StatusCode = %d, want 200", resp.StatusCode)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("Do took %s, want it to honor Retry-After instead of the hour-long backoff base", elapsed)
+	}
+}
+
+func TestDoCanceledContextStopsRetries(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	c := &Client{MaxRetries: 5, RetryBaseDelay: time.Hour}
+	req, err := http.NewRequest(http.MethodGet, srv.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Each attempt's request carries the canceled ctx, so the first attempt
+	// fails before reaching the server, and the wait before the second
+	// attempt returns ctx.Err() instead of sleeping out the hour-long backoff.
+	_, err = c.Do(ctx, req)
+	if err == nil {
+		t.Fatal("Do: want error, got nil")
+	}
+}
+
+func TestNextPageURL(t *testing.T) {
+	cases := []struct {
+		name   string
+		header http.Header
+		want   string
+		wantOK bool
+	}{
+		{
+			name:   "next present",
+			header: http.Header{"Link": []string{`<https://api.example.com/items?page=2>; rel="next"`}},
+			want:   "https://api.example.com/items?page=2",
+			wantOK: true,
+		},
+		{
+			name:   "multiple rels, next among them",
+			header: http.Header{"Link": []string{`<https://api.example.com/items?page=1>; rel="prev", <https://api.example.com/items?page=3>; rel="next"`}},
+			want:   "https://api.example.com/items?page=3",
+			wantOK: true,
+		},
+		{
+			name:   "no next",
+			header: http.Header{"Link": []string{`<https://api.example.com/items?page=1>; rel="prev"`}},
+			want:   "",
+			wantOK: false,
+		},
+		{
+			name:   "no Link header",
+			header: http.Header{},
+			want:   "",
+			wantOK: false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, ok := NextPageURL(tc.header)
+			if got != tc.want || ok != tc.wantOK {
+				t.Fatalf("NextPageURL() = (%q, %v), want (%q, %v)", got, ok, tc.want, tc.wantOK)
+			}
+		})
+	}
+}
+
+func TestParseRetryAfterSeconds(t *testing.T) {
+	d, ok := parseRetryAfter("5")
+	if !ok || d != 5*time.Second {
+		t.Fatalf("parseRetryAfter(%q) = (%s, %v), want (5s, true)", "5", d, ok)
+	}
+}
+
+func TestParseRetryAfterEmpty(t *testing.T) {
+	if _, ok := parseRetryAfter(""); ok {
+		t.Fatal("parseRetryAfter(\"\") = ok, want false")
+	}
+}
+
+func TestParseRetryAfterHTTPDate(t *testing.T) {
+	future := time.Now().Add(10 * time.Second).UTC()
+	d, ok := parseRetryAfter(future.Format(http.TimeFormat))
+	if !ok {
+		t.Fatalf("parseRetryAfter(%q): ok = false, want true", future.Format(http.TimeFormat))
+	}
+	if d <= 0 || d > 11*time.Second {
+		t.Fatalf("parseRetryAfter(%q) = %s, want ~10s", future.Format(http.TimeFormat), d)
+	}
+}
+
+func TestParseRetryAfterNonNumericNonDate(t *testing.T) {
+	if _, ok := parseRetryAfter("not-a-delay"); ok {
+		t.Fatalf("parseRetryAfter(%q): ok = true, want false", "not-a-delay")
+	}
+}
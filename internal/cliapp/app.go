@@ -0,0 +1,20 @@
+// Package cliapp wraps *cli.App with opt-in cross-cutting subsystems
+// (config loading, shell completion, safety middleware, plugin discovery)
+// so every example CLI in this repo can pull them in with a single call
+// instead of reimplementing the same boilerplate per app.
+package cliapp
+
+import "github.com/urfave/cli/v2"
+
+// App embeds *cli.App so it can be used as a drop-in replacement: callers
+// keep writing app.Run(os.Args) and app.Commands as before, and opt into
+// subsystems with the Enable*/Install*/Use* methods below.
+type App struct {
+	*cli.App
+}
+
+// New wraps an existing *cli.App. It does not mutate a, so it is safe to
+// call before or after a's Flags/Commands are populated.
+func New(a *cli.App) *App {
+	return &App{App: a}
+}
@@ -0,0 +1,62 @@
+package cliapp
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/vanman2024/cli-builder/internal/completion"
+)
+
+// InstallCompletion adds a `completion` command with one subcommand per
+// supported shell. `completion <shell>` prints the script to stdout (the
+// usual `source <(app completion bash)` idiom); `completion <shell>
+// --install` writes it to that shell's conventional completions directory.
+func (a *App) InstallCompletion() {
+	a.Commands = append(a.Commands, &cli.Command{
+		Name:  "completion",
+		Usage: "generate shell completion scripts",
+		Subcommands: []*cli.Command{
+			shellCommand(a.App, completion.Bash),
+			shellCommand(a.App, completion.Zsh),
+			shellCommand(a.App, completion.Fish),
+			shellCommand(a.App, completion.PowerShell),
+		},
+	})
+}
+
+func shellCommand(app *cli.App, shell completion.Shell) *cli.Command {
+	return &cli.Command{
+		Name:  string(shell),
+		Usage: fmt.Sprintf("generate the %s completion script", shell),
+		Flags: []cli.Flag{
+			&cli.BoolFlag{Name: "install", Usage: "write the script to the default completions path instead of stdout"},
+		},
+		Action: func(c *cli.Context) error {
+			script, err := completion.Generate(app, shell)
+			if err != nil {
+				return err
+			}
+
+			if !c.Bool("install") {
+				fmt.Print(script)
+				return nil
+			}
+
+			home, err := os.UserHomeDir()
+			if err != nil {
+				return err
+			}
+			path, err := completion.InstallPath(home, app.Name, shell)
+			if err != nil {
+				return err
+			}
+			if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+				return err
+			}
+			return os.WriteFile(path, []byte(script), 0o644)
+		},
+	}
+}
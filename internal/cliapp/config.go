@@ -0,0 +1,143 @@
+package cliapp
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/vanman2024/cli-builder/internal/config"
+)
+
+const originsKey = "cliapp.config.origins"
+
+// ConfigOptions controls EnableConfig. AppName picks the config file name
+// ("<AppName>.yaml" etc.) and the XDG/etc subdirectory searched.
+type ConfigOptions struct {
+	AppName string
+}
+
+// EnableConfig installs a --config override flag and a `config dump`
+// subcommand, and wires a Before hook that fills in any flag left unset on
+// the command line or by its EnvVars from the resolved config file.
+// Precedence ends up CLI flag > env var > config file > flag default,
+// because urfave/cli already resolves CLI-vs-env before Before ever runs;
+// this hook only ever fills in flags that are still unset at that point.
+func (a *App) EnableConfig(opts ConfigOptions) {
+	a.Flags = append(a.Flags, &cli.StringFlag{
+		Name:  "config",
+		Usage: "path to a config file (overrides the search path)",
+	})
+
+	// loadConfig must run before the app's own pre-existing Before: that
+	// hook is typically what reads the now-resolved flags into the
+	// long-lived context struct it caches in Metadata, so config-file
+	// values have to land on the flags first or they never reach it.
+	prevBefore := a.Before
+	a.Before = func(c *cli.Context) error {
+		if err := loadConfig(c, opts.AppName); err != nil {
+			return err
+		}
+		if prevBefore != nil {
+			return prevBefore(c)
+		}
+		return nil
+	}
+
+	a.Commands = append(a.Commands, &cli.Command{
+		Name:  "config",
+		Usage: "inspect the effective configuration",
+		Subcommands: []*cli.Command{
+			{
+				Name:  "dump",
+				Usage: "print the effective merged config and where each value came from",
+				Action: func(c *cli.Context) error {
+					return dumpConfig(c)
+				},
+			},
+		},
+	})
+}
+
+func loadConfig(c *cli.Context, appName string) error {
+	path, err := config.Find(appName, c.String("config"))
+	if err != nil {
+		return err
+	}
+
+	values, err := config.Load(path)
+	if err != nil {
+		return err
+	}
+
+	origins := map[string]config.Origin{}
+	for _, flag := range c.App.Flags {
+		for _, name := range flag.Names() {
+			switch {
+			case !c.IsSet(name):
+				if v, ok := values[name]; ok {
+					if err := c.Set(name, fmt.Sprint(v)); err != nil {
+						return fmt.Errorf("config: applying %q from %s: %w", name, path, err)
+					}
+					origins[name] = config.OriginFile
+				} else {
+					origins[name] = config.OriginDefault
+				}
+			case explicitlySetOnCommandLine(flag):
+				origins[name] = config.OriginFlag
+			default:
+				// c.IsSet is true but the flag wasn't passed on argv, so
+				// urfave/cli must have resolved it from EnvVars.
+				origins[name] = config.OriginEnv
+			}
+		}
+	}
+
+	if c.App.Metadata == nil {
+		c.App.Metadata = map[string]interface{}{}
+	}
+	c.App.Metadata[originsKey] = origins
+	return nil
+}
+
+// Source reports where c's value for flag name ultimately came from:
+// "flag", "env", "file", or "default". It is the cli.Context.Source
+// urfave/cli doesn't have, kept as a package function since Context is
+// not ours to add methods to. The origin is recorded once in loadConfig,
+// since it can't be reconstructed reliably afterwards.
+func Source(c *cli.Context, name string) string {
+	origins, _ := c.App.Metadata[originsKey].(map[string]config.Origin)
+	if origin, ok := origins[name]; ok {
+		return string(origin)
+	}
+	return string(config.OriginDefault)
+}
+
+// explicitlySetOnCommandLine reports whether one of flag's names was
+// passed as a "--name"/"-name" argument. c.IsSet can't make this
+// distinction: urfave/cli marks a flag set the same way whether its
+// value came from argv or from an EnvVars lookup.
+func explicitlySetOnCommandLine(flag cli.Flag) bool {
+	for _, name := range flag.Names() {
+		long, short := "--"+name, "-"+name
+		for _, arg := range os.Args[1:] {
+			arg, _, _ = strings.Cut(arg, "=")
+			if arg == long || arg == short {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func dumpConfig(c *cli.Context) error {
+	origins, _ := c.App.Metadata[originsKey].(map[string]config.Origin)
+	for _, flag := range c.App.Flags {
+		for _, name := range flag.Names() {
+			fmt.Printf("%s = %v (%s)\n", name, c.Value(name), Source(c, name))
+		}
+	}
+	_ = origins
+	return nil
+}
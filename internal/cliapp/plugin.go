@@ -0,0 +1,88 @@
+package cliapp
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/vanman2024/cli-builder/internal/plugin"
+)
+
+// EnablePlugins scans $PATH for executables named "<prefix>-<verb>"
+// (following the git/kubectl model) and registers each as a synthetic
+// "<verb>" command: running it execs the plugin binary with the
+// remaining args and the app's own flags exported as
+// "<PREFIX>_<FLAG>=value" environment variables. The scan result is
+// cached in $XDG_CACHE_HOME, keyed by each binary's mtime and size, so a
+// repeat run only re-probes plugins that changed. It also adds a
+// `plugin list` command that prints what was discovered.
+func (a *App) EnablePlugins(prefix string) {
+	plugins, err := plugin.Discover(prefix, plugin.CachePath(a.Name))
+	if err != nil {
+		plugins = nil
+	}
+
+	for _, p := range plugins {
+		a.Commands = append(a.Commands, pluginCommand(prefix, p))
+	}
+
+	a.Commands = append(a.Commands, &cli.Command{
+		Name:  "plugin",
+		Usage: "inspect plugins discovered on PATH",
+		Subcommands: []*cli.Command{
+			{
+				Name:  "list",
+				Usage: "list discovered plugins and where they were found",
+				Action: func(c *cli.Context) error {
+					for _, p := range plugins {
+						fmt.Printf("%-15s %-10s %s\t(%s)\n", p.Verb, p.Category, p.Usage, p.Path)
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
+// pluginCommand wraps p as a *cli.Command whose Action execs the plugin
+// binary, so it shows up in `app help` and `app <verb> --help` like any
+// builtin command.
+func pluginCommand(prefix string, p plugin.Plugin) *cli.Command {
+	flags := make([]cli.Flag, len(p.Flags))
+	for i, f := range p.Flags {
+		flags[i] = &cli.StringFlag{Name: f.Name, Usage: f.Usage}
+	}
+
+	return &cli.Command{
+		Name:            p.Verb,
+		Usage:           p.Usage,
+		Category:        p.Category,
+		Flags:           flags,
+		SkipFlagParsing: true,
+		Action: func(c *cli.Context) error {
+			env := exportFlagsAsEnv(prefix, c)
+			if err := p.Exec(c.Args().Slice(), env); err != nil {
+				return fmt.Errorf("plugin: running %s: %w", p.Path, err)
+			}
+			return nil
+		},
+	}
+}
+
+// exportFlagsAsEnv renders every flag set on c's app as
+// "<PREFIX>_<FLAG>=value" so a plugin binary can read its parent's
+// global configuration (--url, --token, ...) without the parent having
+// to know which of them the plugin cares about.
+func exportFlagsAsEnv(prefix string, c *cli.Context) []string {
+	var env []string
+	for _, flag := range c.App.Flags {
+		for _, name := range flag.Names() {
+			if v := c.String(name); v != "" {
+				key := strings.ToUpper(prefix) + "_" + strings.ToUpper(strings.ReplaceAll(name, "-", "_"))
+				env = append(env, key+"="+v)
+			}
+		}
+	}
+	return env
+}
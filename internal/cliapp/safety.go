@@ -0,0 +1,22 @@
+package cliapp
+
+import (
+	"github.com/urfave/cli/v2"
+
+	"github.com/vanman2024/cli-builder/internal/safety"
+)
+
+// UseSafety adds the shared --yes/-y, --dry-run, and --assume-no flags and
+// wraps every command (recursively) previously registered with
+// safety.MarkDestructive so it honors them uniformly.
+func (a *App) UseSafety() {
+	a.Flags = append(a.Flags, safety.Flags()...)
+	wrapAll(a.Commands)
+}
+
+func wrapAll(cmds []*cli.Command) {
+	for _, cmd := range cmds {
+		safety.Wrap(cmd)
+		wrapAll(cmd.Subcommands)
+	}
+}
@@ -0,0 +1,128 @@
+// Package clilog replaces the ad-hoc fmt.Println tracing scattered through
+// this repo's examples with structured, leveled logging shared via
+// App.Metadata, plus automatic per-command timing.
+package clilog
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/urfave/cli/v2"
+)
+
+const metadataKey = "logger"
+
+// LevelFlag and FormatFlag are appended to an app's global Flags by Install.
+var (
+	LevelFlag = &cli.StringFlag{
+		Name:  "log-level",
+		Usage: "log level: trace, debug, info, warn, error",
+		Value: "info",
+	}
+	FormatFlag = &cli.StringFlag{
+		Name:  "log-format",
+		Usage: "log format: text or json",
+		Value: "text",
+	}
+)
+
+// Install adds --log-level/--log-format, a Before hook that builds a
+// *slog.Logger into c.App.Metadata["logger"], and wraps every command (and
+// its subcommands) so each emits a single "command.completed" event on
+// exit with its duration, arg count, and error.
+func Install(app *cli.App) {
+	app.Flags = append(app.Flags, LevelFlag, FormatFlag)
+
+	prevBefore := app.Before
+	app.Before = func(c *cli.Context) error {
+		if c.App.Metadata == nil {
+			c.App.Metadata = map[string]interface{}{}
+		}
+		c.App.Metadata[metadataKey] = newLogger(c.String("log-level"), c.String("log-format"))
+		if prevBefore != nil {
+			return prevBefore(c)
+		}
+		return nil
+	}
+
+	for _, cmd := range app.Commands {
+		wrap(cmd)
+	}
+}
+
+// Logger returns the logger Install stashed in c.App.Metadata, or a
+// default stderr text logger if Install was never called.
+func Logger(c *cli.Context) *slog.Logger {
+	if logger, ok := c.App.Metadata[metadataKey].(*slog.Logger); ok {
+		return logger
+	}
+	return slog.New(slog.NewTextHandler(os.Stderr, nil))
+}
+
+func newLogger(level, format string) *slog.Logger {
+	opts := &slog.HandlerOptions{Level: parseLevel(level)}
+	if format == "json" {
+		return slog.New(slog.NewJSONHandler(os.Stderr, opts))
+	}
+	return slog.New(slog.NewTextHandler(os.Stderr, opts))
+}
+
+func parseLevel(level string) slog.Level {
+	switch level {
+	case "trace", "debug":
+		return slog.LevelDebug
+	case "warn":
+		return slog.LevelWarn
+	case "error":
+		return slog.LevelError
+	default:
+		return slog.LevelInfo
+	}
+}
+
+func wrap(cmd *cli.Command) {
+	for _, sub := range cmd.Subcommands {
+		wrap(sub)
+	}
+
+	before, action, after := cmd.Before, cmd.Action, cmd.After
+	var start time.Time
+	var actionErr error
+
+	cmd.Before = func(c *cli.Context) error {
+		start = time.Now()
+		if before != nil {
+			return before(c)
+		}
+		return nil
+	}
+
+	if action != nil {
+		cmd.Action = func(c *cli.Context) error {
+			actionErr = action(c)
+			return actionErr
+		}
+	}
+
+	cmd.After = func(c *cli.Context) error {
+		Logger(c).Info("command.completed",
+			"command", cmd.Name,
+			"duration", time.Since(start).String(),
+			"args", c.NArg(),
+			"error", errString(actionErr),
+		)
+		if after != nil {
+			return after(c)
+		}
+		return nil
+	}
+}
+
+func errString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return fmt.Sprint(err)
+}
@@ -0,0 +1,176 @@
+// Package completion generates shell completion scripts (bash, zsh, fish,
+// powershell) from a *cli.App's commands, subcommands, flags and aliases.
+package completion
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+)
+
+// Shell identifies a supported target shell.
+type Shell string
+
+const (
+	Bash       Shell = "bash"
+	Zsh        Shell = "zsh"
+	Fish       Shell = "fish"
+	PowerShell Shell = "powershell"
+)
+
+// InstallPath returns the conventional location a completion script for
+// shell would be installed to for appName, relative to $HOME.
+func InstallPath(home, appName string, shell Shell) (string, error) {
+	switch shell {
+	case Bash:
+		return home + "/.local/share/bash-completion/completions/" + appName, nil
+	case Zsh:
+		return home + "/.zsh/completions/_" + appName, nil
+	case Fish:
+		return home + "/.config/fish/completions/" + appName + ".fish", nil
+	case PowerShell:
+		return home + "/.config/powershell/completions/" + appName + ".ps1", nil
+	default:
+		return "", fmt.Errorf("completion: unsupported shell %q", shell)
+	}
+}
+
+// Generate renders the completion script for app and shell.
+func Generate(app *cli.App, shell Shell) (string, error) {
+	switch shell {
+	case Bash:
+		return bash(app), nil
+	case Zsh:
+		return zsh(app), nil
+	case Fish:
+		return fish(app), nil
+	case PowerShell:
+		return powershell(app), nil
+	default:
+		return "", fmt.Errorf("completion: unsupported shell %q", shell)
+	}
+}
+
+// allNames returns every command and subcommand name/alias, recursing
+// into Subcommands so nested commands (e.g. completion's own
+// "bash"/"zsh"/"fish"/"powershell" subcommands) are offered too.
+func allNames(app *cli.App) []string {
+	var names []string
+	for _, cmd := range app.Commands {
+		names = append(names, commandNames(cmd)...)
+	}
+	return names
+}
+
+func commandNames(cmd *cli.Command) []string {
+	names := append([]string{}, cmd.Names()...)
+	for _, sub := range cmd.Subcommands {
+		names = append(names, commandNames(sub)...)
+	}
+	return names
+}
+
+func allFlags(app *cli.App) []string {
+	var flags []string
+	for _, f := range app.Flags {
+		for _, name := range f.Names() {
+			if len(name) == 1 {
+				flags = append(flags, "-"+name)
+			} else {
+				flags = append(flags, "--"+name)
+			}
+		}
+	}
+	return flags
+}
+
+func bash(app *cli.App) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# bash completion for %s\n", app.Name)
+	fmt.Fprintf(&b, "_%s_completions() {\n", app.Name)
+	fmt.Fprintf(&b, "  COMPREPLY=($(compgen -W \"%s %s\" -- \"${COMP_WORDS[COMP_CWORD]}\"))\n",
+		strings.Join(allNames(app), " "), strings.Join(allFlags(app), " "))
+	fmt.Fprintln(&b, "}")
+	fmt.Fprintf(&b, "complete -F _%s_completions %s\n", app.Name, app.Name)
+	writeDynamicHooks(&b, app, Bash)
+	return b.String()
+}
+
+func zsh(app *cli.App) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "#compdef %s\n", app.Name)
+	fmt.Fprintf(&b, "_%s() {\n", app.Name)
+	fmt.Fprintf(&b, "  _values '%s command' %s\n", app.Name, strings.Join(quoteAll(allNames(app)), " "))
+	fmt.Fprintln(&b, "}")
+	fmt.Fprintf(&b, "compdef _%s %s\n", app.Name, app.Name)
+	writeDynamicHooks(&b, app, Zsh)
+	return b.String()
+}
+
+func fish(app *cli.App) string {
+	var b strings.Builder
+	writeFishCommands(&b, app, app.Commands)
+	for _, flag := range allFlags(app) {
+		fmt.Fprintf(&b, "complete -c %s -l %s\n", app.Name, strings.TrimLeft(flag, "-"))
+	}
+	writeDynamicHooks(&b, app, Fish)
+	return b.String()
+}
+
+// writeFishCommands emits one "complete" line per command name/alias in
+// cmds, recursing into each command's Subcommands.
+func writeFishCommands(b *strings.Builder, app *cli.App, cmds []*cli.Command) {
+	for _, cmd := range cmds {
+		for _, name := range cmd.Names() {
+			fmt.Fprintf(b, "complete -c %s -n '__fish_use_subcommand' -a %s -d '%s'\n",
+				app.Name, name, cmd.Usage)
+		}
+		writeFishCommands(b, app, cmd.Subcommands)
+	}
+}
+
+func powershell(app *cli.App) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Register-ArgumentCompleter -Native -CommandName %s -ScriptBlock {\n", app.Name)
+	fmt.Fprintf(&b, "  param($wordToComplete, $commandAst, $cursorPosition)\n")
+	fmt.Fprintf(&b, "  @(%s) | Where-Object { $_ -like \"$wordToComplete*\" }\n",
+		strings.Join(quoteAll(append(allNames(app), allFlags(app)...)), ", "))
+	fmt.Fprintln(&b, "}")
+	return b.String()
+}
+
+// writeDynamicHooks handles the app's and top-level commands' flags that
+// have values registered in DynamicValues (see hooks.go). Only fish gets
+// real value completion (a "complete -l" line per flag); bash gets a
+// comment line listing the values, and zsh gets nothing. Flags of nested
+// subcommands are not looked at.
+func writeDynamicHooks(b *strings.Builder, app *cli.App, shell Shell) {
+	writeDynamicHooksFor(b, app, app.Name, app.Flags, shell)
+	for _, cmd := range app.Commands {
+		writeDynamicHooksFor(b, app, cmd.Name, cmd.Flags, shell)
+	}
+}
+
+func writeDynamicHooksFor(b *strings.Builder, app *cli.App, scope string, flags []cli.Flag, shell Shell) {
+	for _, flag := range flags {
+		values, ok := DynamicValues[flagKey(scope, flag.Names()[0])]
+		if !ok {
+			continue
+		}
+		switch shell {
+		case Bash:
+			fmt.Fprintf(b, "# dynamic: %s --%s -> %s\n", scope, flag.Names()[0], strings.Join(values, "|"))
+		case Fish:
+			fmt.Fprintf(b, "complete -c %s -l %s -a '%s'\n", app.Name, flag.Names()[0], strings.Join(values, " "))
+		}
+	}
+}
+
+func quoteAll(in []string) []string {
+	out := make([]string, len(in))
+	for i, s := range in {
+		out[i] = "'" + s + "'"
+	}
+	return out
+}
@@ -0,0 +1,18 @@
+package completion
+
+// DynamicValues registers static-ish value sets for a command's flag, keyed
+// by flagKey(commandName, flagName). Apps populate this in an init() next
+// to their flag definitions (e.g. deploy's --env completing
+// dev|staging|production) since urfave/cli's own BashComplete hook only
+// fires for an already-running bash, not for script generation.
+var DynamicValues = map[string][]string{}
+
+// Register adds (or replaces) the completion values for commandName's
+// flagName.
+func Register(commandName, flagName string, values []string) {
+	DynamicValues[flagKey(commandName, flagName)] = values
+}
+
+func flagKey(commandName, flagName string) string {
+	return commandName + "\x00" + flagName
+}
@@ -0,0 +1,95 @@
+// Package config implements layered configuration loading for the example
+// CLIs: command-line flags take precedence over environment variables,
+// which take precedence over a config file, which takes precedence over a
+// flag's own default value.
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// Origin identifies where a flag's effective value came from.
+type Origin string
+
+const (
+	OriginDefault Origin = "default"
+	OriginFile    Origin = "file"
+	OriginEnv     Origin = "env"
+	OriginFlag    Origin = "flag"
+)
+
+// SearchPaths returns the default, ordered list of directories config
+// loading looks in for "<appName>.{yaml,yml,toml,json}". Find only
+// searches them when no explicit --config override is given.
+func SearchPaths(appName string) []string {
+	paths := []string{"."}
+
+	if xdg := os.Getenv("XDG_CONFIG_HOME"); xdg != "" {
+		paths = append(paths, filepath.Join(xdg, appName))
+	} else if home, err := os.UserHomeDir(); err == nil {
+		paths = append(paths, filepath.Join(home, ".config", appName))
+	}
+
+	paths = append(paths, filepath.Join("/etc", appName))
+	return paths
+}
+
+// Find locates the config file for appName. A non-empty override is
+// returned as is (or an error if it does not exist) without searching;
+// otherwise the first existing file in SearchPaths(appName) wins.
+func Find(appName, override string) (string, error) {
+	if override != "" {
+		if _, err := os.Stat(override); err != nil {
+			return "", fmt.Errorf("config: --config %s: %w", override, err)
+		}
+		return override, nil
+	}
+
+	for _, dir := range SearchPaths(appName) {
+		for _, ext := range []string{"yaml", "yml", "toml", "json"} {
+			candidate := filepath.Join(dir, appName+"."+ext)
+			if _, err := os.Stat(candidate); err == nil {
+				return candidate, nil
+			}
+		}
+	}
+
+	return "", nil
+}
+
+// Load reads and decodes the config file at path into a generic map, using
+// the file extension to pick a decoder. A non-existent path (empty string,
+// meaning none was found) returns an empty map and no error.
+func Load(path string) (map[string]any, error) {
+	values := map[string]any{}
+	if path == "" {
+		return values, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("config: reading %s: %w", path, err)
+	}
+
+	switch filepath.Ext(path) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &values)
+	case ".toml":
+		err = toml.Unmarshal(data, &values)
+	case ".json":
+		err = json.Unmarshal(data, &values)
+	default:
+		return nil, fmt.Errorf("config: unsupported format %q", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("config: parsing %s: %w", path, err)
+	}
+
+	return values, nil
+}
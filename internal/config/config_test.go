@@ -0,0 +1,197 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFindPrefersOverride(t *testing.T) {
+	dir := t.TempDir()
+	override := filepath.Join(dir, "explicit.yaml")
+	if err := os.WriteFile(override, []byte("{}"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	// A config file that would otherwise win the search still loses to an
+	// explicit override.
+	chdir(t, dir)
+	if err := os.WriteFile("myapp.yaml", []byte("{}"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := Find("myapp", override)
+	if err != nil {
+		t.Fatalf("Find: %v", err)
+	}
+	if got != override {
+		t.Fatalf("Find() = %q, want override %q", got, override)
+	}
+}
+
+func TestFindOverrideMissingIsError(t *testing.T) {
+	if _, err := Find("myapp", filepath.Join(t.TempDir(), "missing.yaml")); err == nil {
+		t.Fatal("Find: want error for a --config path that doesn't exist, got nil")
+	}
+}
+
+func TestFindPrefersCWDOverXDGConfigHome(t *testing.T) {
+	xdg := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(xdg, "myapp"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(xdg, "myapp", "myapp.yaml"), []byte("{}"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("XDG_CONFIG_HOME", xdg)
+
+	cwd := t.TempDir()
+	chdir(t, cwd)
+	if err := os.WriteFile("myapp.toml", []byte("{}"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := Find("myapp", "")
+	if err != nil {
+		t.Fatalf("Find: %v", err)
+	}
+	want := filepath.Join(".", "myapp.toml")
+	if got != want {
+		t.Fatalf("Find() = %q, want cwd's %q over XDG_CONFIG_HOME's copy", got, want)
+	}
+}
+
+func TestFindFallsBackToXDGConfigHome(t *testing.T) {
+	xdg := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(xdg, "myapp"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(xdg, "myapp", "myapp.json"), []byte("{}"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("XDG_CONFIG_HOME", xdg)
+	chdir(t, t.TempDir())
+
+	got, err := Find("myapp", "")
+	if err != nil {
+		t.Fatalf("Find: %v", err)
+	}
+	want := filepath.Join(xdg, "myapp", "myapp.json")
+	if got != want {
+		t.Fatalf("Find() = %q, want %q", got, want)
+	}
+}
+
+func TestFindSearchesExtensionsInOrder(t *testing.T) {
+	cases := []struct {
+		name    string
+		present []string
+		want    string
+	}{
+		{name: "yaml wins over yml/toml/json", present: []string{"yaml", "yml", "toml", "json"}, want: "yaml"},
+		{name: "yml wins when yaml absent", present: []string{"yml", "toml", "json"}, want: "yml"},
+		{name: "toml wins when yaml/yml absent", present: []string{"toml", "json"}, want: "toml"},
+		{name: "json is the last resort", present: []string{"json"}, want: "json"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for _, ext := range tc.present {
+				path := filepath.Join(dir, "myapp."+ext)
+				if err := os.WriteFile(path, []byte("{}"), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			chdir(t, dir)
+			t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+			got, err := Find("myapp", "")
+			if err != nil {
+				t.Fatalf("Find: %v", err)
+			}
+			want := filepath.Join(".", "myapp."+tc.want)
+			if got != want {
+				t.Fatalf("Find() = %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestFindNoMatchReturnsEmpty(t *testing.T) {
+	chdir(t, t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	got, err := Find("myapp", "")
+	if err != nil {
+		t.Fatalf("Find: %v", err)
+	}
+	if got != "" {
+		t.Fatalf("Find() = %q, want \"\" when no config file exists anywhere on the search path", got)
+	}
+}
+
+func TestLoadDispatchesByExtension(t *testing.T) {
+	cases := []struct {
+		name string
+		ext  string
+		data string
+		want string
+	}{
+		{name: "yaml", ext: "yaml", data: "key: value\n", want: "value"},
+		{name: "toml", ext: "toml", data: "key = \"value\"\n", want: "value"},
+		{name: "json", ext: "json", data: `{"key":"value"}`, want: "value"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "myapp."+tc.ext)
+			if err := os.WriteFile(path, []byte(tc.data), 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			values, err := Load(path)
+			if err != nil {
+				t.Fatalf("Load: %v", err)
+			}
+			if got := values["key"]; got != tc.want {
+				t.Fatalf("Load()[%q] = %v, want %q", "key", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestLoadEmptyPathReturnsEmptyMap(t *testing.T) {
+	values, err := Load("")
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if len(values) != 0 {
+		t.Fatalf("Load(\"\") = %v, want empty map", values)
+	}
+}
+
+func TestLoadUnsupportedExtension(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "myapp.ini")
+	if err := os.WriteFile(path, []byte("key=value"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(path); err == nil {
+		t.Fatal("Load: want error for an unsupported extension, got nil")
+	}
+}
+
+// chdir changes the working directory to dir for the duration of t,
+// restoring the original on cleanup.
+func chdir(t *testing.T, dir string) {
+	t.Helper()
+	old, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = os.Chdir(old)
+	})
+}
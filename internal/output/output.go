@@ -0,0 +1,144 @@
+// Package output renders API responses in the formats the api example CLI
+// offers via --output, optionally narrowed first by a --jq filter.
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/itchyny/gojq"
+	"gopkg.in/yaml.v3"
+)
+
+// Format is a supported --output value.
+type Format string
+
+const (
+	JSON  Format = "json"
+	YAML  Format = "yaml"
+	Table Format = "table"
+)
+
+// Filter runs expr (a jq program) over the JSON document in body and
+// returns the resulting values re-encoded as JSON. An empty expr is a
+// no-op.
+func Filter(body []byte, expr string) ([]byte, error) {
+	if expr == "" {
+		return body, nil
+	}
+
+	query, err := gojq.Parse(expr)
+	if err != nil {
+		return nil, fmt.Errorf("output: parsing --jq %q: %w", expr, err)
+	}
+
+	var input any
+	if err := json.Unmarshal(body, &input); err != nil {
+		return nil, fmt.Errorf("output: decoding response as JSON: %w", err)
+	}
+
+	var results []any
+	iter := query.Run(input)
+	for {
+		v, ok := iter.Next()
+		if !ok {
+			break
+		}
+		if err, ok := v.(error); ok {
+			return nil, fmt.Errorf("output: evaluating --jq %q: %w", expr, err)
+		}
+		results = append(results, v)
+	}
+
+	if len(results) == 1 {
+		return json.Marshal(results[0])
+	}
+	return json.Marshal(results)
+}
+
+// Render formats the JSON document in body as format.
+func Render(body []byte, format Format) (string, error) {
+	var value any
+	if err := json.Unmarshal(body, &value); err != nil {
+		return "", fmt.Errorf("output: decoding response as JSON: %w", err)
+	}
+
+	switch format {
+	case "", JSON:
+		pretty, err := json.MarshalIndent(value, "", "  ")
+		if err != nil {
+			return "", fmt.Errorf("output: encoding json: %w", err)
+		}
+		return string(pretty), nil
+
+	case YAML:
+		var buf bytes.Buffer
+		enc := yaml.NewEncoder(&buf)
+		enc.SetIndent(2)
+		if err := enc.Encode(value); err != nil {
+			return "", fmt.Errorf("output: encoding yaml: %w", err)
+		}
+		enc.Close()
+		return buf.String(), nil
+
+	case Table:
+		return renderTable(value), nil
+
+	default:
+		return "", fmt.Errorf("output: unsupported --output %q", format)
+	}
+}
+
+// renderTable renders a []any of objects as a tab-separated table with
+// one column per key, sorted by name; cells are not padded. Any
+// other shape is rendered as a single "value" column; this is a best
+// effort for ad-hoc API responses, not a general tabular formatter.
+func renderTable(value any) string {
+	rows, ok := value.([]any)
+	if !ok {
+		rows = []any{value}
+	}
+
+	columns := []string{}
+	seen := map[string]bool{}
+	for _, row := range rows {
+		obj, ok := row.(map[string]any)
+		if !ok {
+			continue
+		}
+		for key := range obj {
+			if !seen[key] {
+				seen[key] = true
+				columns = append(columns, key)
+			}
+		}
+	}
+	sort.Strings(columns)
+	if len(columns) == 0 {
+		columns = []string{"value"}
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(strings.Join(columns, "\t"))
+	buf.WriteByte('\n')
+	for _, row := range rows {
+		obj, ok := row.(map[string]any)
+		if !ok {
+			buf.WriteString(fmt.Sprint(row))
+			buf.WriteByte('\n')
+			continue
+		}
+		cells := make([]string, len(columns))
+		for i, col := range columns {
+			if v, ok := obj[col]; ok {
+				cells[i] = fmt.Sprint(v)
+			}
+		}
+		buf.WriteString(strings.Join(cells, "\t"))
+		buf.WriteByte('\n')
+	}
+	return buf.String()
+}
@@ -0,0 +1,204 @@
+// Package plugin implements git/kubectl-style plugin discovery: any
+// executable named "<prefix>-<verb>" found on $PATH is probed for
+// metadata and can then be dispatched as "<prefix> <verb>".
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// probeTimeout bounds how long a candidate binary gets to answer
+// MetadataProbeFlag before it's treated as a failed probe. Without a
+// timeout, a stray PATH entry that matches "<prefix>-*" but doesn't
+// implement the metadata protocol (and just sits there) would hang
+// Discover, and with it every invocation of the host CLI, forever.
+const probeTimeout = 3 * time.Second
+
+// MetadataProbeFlag is the flag Discover invokes every candidate binary
+// with; a plugin is expected to print its Metadata as JSON to stdout and
+// exit 0.
+const MetadataProbeFlag = "--cli-plugin-metadata"
+
+// Flag describes one flag a plugin command accepts, as reported by its
+// metadata probe.
+type Flag struct {
+	Name  string `json:"name"`
+	Usage string `json:"usage"`
+}
+
+// Metadata is what a plugin binary reports about itself in response to
+// MetadataProbeFlag.
+type Metadata struct {
+	Name     string `json:"name"`
+	Usage    string `json:"usage"`
+	Category string `json:"category"`
+	Flags    []Flag `json:"flags"`
+}
+
+// Plugin is one discovered "<prefix>-<verb>" binary, combined with its
+// probed Metadata.
+type Plugin struct {
+	Verb string
+	Path string
+	Metadata
+}
+
+// Exec runs the plugin with args, exporting extraEnv in addition to the
+// current process's environment, and inheriting stdio so the plugin can
+// prompt and stream output exactly like a builtin command would.
+func (p Plugin) Exec(args []string, extraEnv []string) error {
+	cmd := exec.Command(p.Path, args...)
+	cmd.Env = append(os.Environ(), extraEnv...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
+
+// cacheEntry is one plugin's cached probe result, invalidated whenever
+// the binary's mtime or size changes.
+type cacheEntry struct {
+	ModTime  int64    `json:"mtime"`
+	Size     int64    `json:"size"`
+	Metadata Metadata `json:"metadata"`
+}
+
+// Discover scans $PATH for executables matching "<prefix>-*" and probes
+// each for Metadata, reusing cachePath (a JSON file keyed by binary path)
+// for any candidate whose mtime and size haven't changed since the last
+// scan. Plugins are returned sorted by verb.
+func Discover(prefix, cachePath string) ([]Plugin, error) {
+	cache := loadCache(cachePath)
+	dirty := false
+
+	var plugins []Plugin
+	for _, path := range candidates(prefix) {
+		info, err := os.Stat(path)
+		if err != nil {
+			continue
+		}
+
+		entry, ok := cache[path]
+		if !ok || entry.ModTime != info.ModTime().Unix() || entry.Size != info.Size() {
+			meta, err := probe(path)
+			if err != nil {
+				continue
+			}
+			entry = cacheEntry{ModTime: info.ModTime().Unix(), Size: info.Size(), Metadata: meta}
+			cache[path] = entry
+			dirty = true
+		}
+
+		plugins = append(plugins, Plugin{
+			Verb:     strings.TrimPrefix(filepath.Base(path), prefix+"-"),
+			Path:     path,
+			Metadata: entry.Metadata,
+		})
+	}
+
+	if dirty {
+		saveCache(cachePath, cache)
+	}
+
+	sort.Slice(plugins, func(i, j int) bool { return plugins[i].Verb < plugins[j].Verb })
+	return plugins, nil
+}
+
+// candidates returns every executable on $PATH named "<prefix>-*", in
+// PATH order, first match per basename winning (the same shadowing rule
+// the shell itself uses for $PATH lookups).
+func candidates(prefix string) []string {
+	seen := map[string]bool{}
+	var paths []string
+
+	for _, dir := range filepath.SplitList(os.Getenv("PATH")) {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			name := entry.Name()
+			if entry.IsDir() || !strings.HasPrefix(name, prefix+"-") || seen[name] {
+				continue
+			}
+			path := filepath.Join(dir, name)
+			info, err := entry.Info()
+			if err != nil || info.Mode()&0o111 == 0 {
+				continue
+			}
+			seen[name] = true
+			paths = append(paths, path)
+		}
+	}
+
+	return paths
+}
+
+// probe invokes path with MetadataProbeFlag and decodes its stdout. A
+// candidate that doesn't answer within probeTimeout is killed and treated
+// as any other probe failure: the caller skips it.
+func probe(path string) (Metadata, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), probeTimeout)
+	defer cancel()
+
+	var stdout bytes.Buffer
+	cmd := exec.CommandContext(ctx, path, MetadataProbeFlag)
+	cmd.Stdout = &stdout
+
+	if err := cmd.Run(); err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return Metadata{}, fmt.Errorf("plugin: probing %s: timed out after %s", path, probeTimeout)
+		}
+		return Metadata{}, fmt.Errorf("plugin: probing %s: %w", path, err)
+	}
+
+	var meta Metadata
+	if err := json.Unmarshal(stdout.Bytes(), &meta); err != nil {
+		return Metadata{}, fmt.Errorf("plugin: decoding metadata from %s: %w", path, err)
+	}
+	return meta, nil
+}
+
+func loadCache(path string) map[string]cacheEntry {
+	cache := map[string]cacheEntry{}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return cache
+	}
+	_ = json.Unmarshal(data, &cache)
+	return cache
+}
+
+func saveCache(path string, cache map[string]cacheEntry) {
+	data, err := json.Marshal(cache)
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return
+	}
+	_ = os.WriteFile(path, data, 0o644)
+}
+
+// CachePath returns the conventional cache file location for appName's
+// plugin scan, under $XDG_CACHE_HOME (or ~/.cache as a fallback).
+func CachePath(appName string) string {
+	if xdg := os.Getenv("XDG_CACHE_HOME"); xdg != "" {
+		return filepath.Join(xdg, appName, "plugins.json")
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return filepath.Join(os.TempDir(), appName, "plugins.json")
+	}
+	return filepath.Join(home, ".cache", appName, "plugins.json")
+}
@@ -0,0 +1,84 @@
+// Package prompt fills in missing required flags interactively when stdin
+// is a terminal, instead of letting urfave/cli hard-fail with a usage error.
+package prompt
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+	"golang.org/x/term"
+)
+
+// Option configures how a single flag is prompted for.
+type Option struct {
+	// Mask hides keystrokes, for secret-like flags (tokens, passwords).
+	Mask bool
+	// Validate rejects the entered value; the user is re-prompted on error.
+	Validate func(string) error
+}
+
+// NoPromptFlag is the shared opt-out flag every app wires into its Flags
+// slice so scripted/CI runs can force the old hard-fail behavior even when
+// stdin happens to be a TTY (e.g. under a pty-backed test harness).
+var NoPromptFlag = &cli.BoolFlag{
+	Name:  "no-prompt",
+	Usage: "disable interactive prompts; fail fast on missing required flags",
+}
+
+// Required returns the value of name from c, prompting for it interactively
+// if it is empty. Non-interactive runs (no TTY, or --no-prompt) return the
+// same "required flag" error the flag parser itself would have produced.
+func Required(c *cli.Context, name string, opt Option) (string, error) {
+	if v := c.String(name); v != "" {
+		return v, nil
+	}
+
+	if c.Bool("no-prompt") || !isTerminal(os.Stdin) {
+		return "", fmt.Errorf("Required flag %q not set", name)
+	}
+
+	for {
+		value, err := read(os.Stdin, os.Stdout, name, opt.Mask)
+		if err != nil {
+			return "", err
+		}
+		if value == "" {
+			fmt.Fprintf(os.Stdout, "%s is required\n", name)
+			continue
+		}
+		if opt.Validate != nil {
+			if verr := opt.Validate(value); verr != nil {
+				fmt.Fprintf(os.Stdout, "%v\n", verr)
+				continue
+			}
+		}
+		return value, nil
+	}
+}
+
+func read(in *os.File, out io.Writer, name string, mask bool) (string, error) {
+	fmt.Fprintf(out, "%s: ", name)
+
+	if mask {
+		b, err := term.ReadPassword(int(in.Fd()))
+		fmt.Fprintln(out)
+		if err != nil {
+			return "", fmt.Errorf("reading %s: %w", name, err)
+		}
+		return strings.TrimSpace(string(b)), nil
+	}
+
+	line, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return "", fmt.Errorf("reading %s: %w", name, err)
+	}
+	return strings.TrimSpace(line), nil
+}
+
+func isTerminal(f *os.File) bool {
+	return term.IsTerminal(int(f.Fd()))
+}
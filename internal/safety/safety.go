@@ -0,0 +1,119 @@
+// Package safety provides shared confirmation-prompt and dry-run
+// middleware for destructive commands (deploy, rollback, restore, vacuum,
+// delete, ...), installed via cliapp.App.UseSafety.
+package safety
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+	"golang.org/x/term"
+)
+
+// Rule describes how a destructive command must be confirmed. Confirm, if
+// set, is a value the user must type verbatim; otherwise a plain y/n prompt
+// is used. ConfirmFunc takes precedence over Confirm when set, and is used
+// to derive the value to type from the resolved *cli.Context (e.g. the
+// target environment passed via --env) instead of a fixed string.
+type Rule struct {
+	Confirm     string
+	ConfirmFunc func(c *cli.Context) string
+}
+
+// resolve returns the value the user must type to confirm, favoring
+// ConfirmFunc over the static Confirm field.
+func (r Rule) resolve(c *cli.Context) string {
+	if r.ConfirmFunc != nil {
+		return r.ConfirmFunc(c)
+	}
+	return r.Confirm
+}
+
+// rules is keyed by command name, the same side-table convention the
+// completion package uses for dynamic BashComplete values, since
+// cli.Command has no extension point of its own for a "Destructive" flag.
+var rules = map[string]Rule{}
+
+// MarkDestructive registers commandName as destructive under rule. Call it
+// from the same init() (or setup code) that builds the command.
+func MarkDestructive(commandName string, rule Rule) {
+	rules[commandName] = rule
+}
+
+// Flags are the shared --yes/-y, --dry-run, and --assume-no flags every
+// app gets via cliapp.App.UseSafety.
+func Flags() []cli.Flag {
+	return []cli.Flag{
+		&cli.BoolFlag{Name: "yes", Aliases: []string{"y"}, Usage: "assume yes and skip confirmation prompts"},
+		&cli.BoolFlag{Name: "dry-run", Usage: "log the intended side effect and exit without executing it"},
+		&cli.BoolFlag{Name: "assume-no", Usage: "assume no; abort destructive commands instead of prompting"},
+	}
+}
+
+// Wrap wraps cmd.Action (if cmd is registered via MarkDestructive) with
+// dry-run and confirmation handling. Safe to call on every command; it is
+// a no-op for commands that were never marked destructive.
+func Wrap(cmd *cli.Command) {
+	rule, ok := rules[cmd.Name]
+	if !ok || cmd.Action == nil {
+		return
+	}
+
+	action := cmd.Action
+	cmd.Action = func(c *cli.Context) error {
+		if c.Bool("dry-run") {
+			fmt.Printf("[dry-run] would run %q (no changes made)\n", cmd.Name)
+			return nil
+		}
+
+		if err := confirm(c, cmd.Name, rule); err != nil {
+			return err
+		}
+
+		return action(c)
+	}
+}
+
+func confirm(c *cli.Context, name string, rule Rule) error {
+	return confirmWith(c, name, rule, os.Stdin, os.Stdout, isTerminal())
+}
+
+// confirmWith implements confirm's prompting logic against an explicit
+// reader/writer/terminal-ness instead of os.Stdin, so the matching rules
+// (typed-confirmation vs. plain y/n, --yes/--assume-no short-circuits) can
+// be exercised without a real TTY.
+func confirmWith(c *cli.Context, name string, rule Rule, in io.Reader, out io.Writer, terminal bool) error {
+	if c.Bool("yes") {
+		return nil
+	}
+
+	if c.Bool("assume-no") || !terminal {
+		return fmt.Errorf("%s is destructive and requires --yes in non-interactive mode", name)
+	}
+
+	reader := bufio.NewReader(in)
+
+	if confirm := rule.resolve(c); confirm != "" {
+		fmt.Fprintf(out, "Type %q to confirm running %q: ", confirm, name)
+		line, _ := reader.ReadString('\n')
+		if strings.TrimSpace(line) != confirm {
+			return fmt.Errorf("%s aborted: confirmation did not match %q", name, confirm)
+		}
+		return nil
+	}
+
+	fmt.Fprintf(out, "Run %q? (y/N): ", name)
+	line, _ := reader.ReadString('\n')
+	if answer := strings.ToLower(strings.TrimSpace(line)); answer != "y" && answer != "yes" {
+		return fmt.Errorf("%s aborted", name)
+	}
+	return nil
+}
+
+func isTerminal() bool {
+	return term.IsTerminal(int(os.Stdin.Fd()))
+}
@@ -0,0 +1,115 @@
+package safety
+
+import (
+	"bytes"
+	"flag"
+	"strings"
+	"testing"
+
+	"github.com/urfave/cli/v2"
+)
+
+// testContext builds a *cli.Context carrying Flags()' --yes/--dry-run/
+// --assume-no flags, set according to bools.
+func testContext(t *testing.T, bools map[string]bool) *cli.Context {
+	t.Helper()
+	set := flag.NewFlagSet("test", flag.ContinueOnError)
+	for _, name := range []string{"yes", "dry-run", "assume-no"} {
+		set.Bool(name, false, "")
+	}
+	for name, v := range bools {
+		if v {
+			if err := set.Set(name, "true"); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	return cli.NewContext(cli.NewApp(), set, nil)
+}
+
+func TestConfirmWithYesSkipsPrompt(t *testing.T) {
+	c := testContext(t, map[string]bool{"yes": true})
+	var out bytes.Buffer
+	if err := confirmWith(c, "deploy", Rule{Confirm: "deploy"}, strings.NewReader(""), &out, false); err != nil {
+		t.Fatalf("confirmWith: %v", err)
+	}
+	if out.Len() != 0 {
+		t.Fatalf("confirmWith with --yes printed a prompt: %q", out.String())
+	}
+}
+
+func TestConfirmWithAssumeNoAborts(t *testing.T) {
+	c := testContext(t, map[string]bool{"assume-no": true})
+	if err := confirmWith(c, "deploy", Rule{}, strings.NewReader(""), &bytes.Buffer{}, true); err == nil {
+		t.Fatal("confirmWith: want error with --assume-no, got nil")
+	}
+}
+
+func TestConfirmWithNonInteractiveRequiresYes(t *testing.T) {
+	c := testContext(t, nil)
+	if err := confirmWith(c, "deploy", Rule{}, strings.NewReader("y\n"), &bytes.Buffer{}, false); err == nil {
+		t.Fatal("confirmWith: want error when not a terminal, got nil")
+	}
+}
+
+func TestConfirmWithTypedConfirmation(t *testing.T) {
+	cases := []struct {
+		name    string
+		input   string
+		rule    Rule
+		wantErr bool
+	}{
+		{name: "exact match succeeds", input: "production\n", rule: Rule{Confirm: "production"}, wantErr: false},
+		{name: "mismatch aborts", input: "prod\n", rule: Rule{Confirm: "production"}, wantErr: true},
+		{name: "surrounding whitespace is trimmed", input: "  production  \n", rule: Rule{Confirm: "production"}, wantErr: false},
+		{name: "empty input aborts", input: "\n", rule: Rule{Confirm: "production"}, wantErr: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := testContext(t, nil)
+			err := confirmWith(c, "deploy", tc.rule, strings.NewReader(tc.input), &bytes.Buffer{}, true)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("confirmWith(%q) error = %v, wantErr %v", tc.input, err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestConfirmWithConfirmFuncOverridesConfirm(t *testing.T) {
+	c := testContext(t, nil)
+	rule := Rule{
+		Confirm:     "static",
+		ConfirmFunc: func(c *cli.Context) string { return "dynamic" },
+	}
+	if err := confirmWith(c, "deploy", rule, strings.NewReader("dynamic\n"), &bytes.Buffer{}, true); err != nil {
+		t.Fatalf("confirmWith: %v", err)
+	}
+	if err := confirmWith(c, "deploy", rule, strings.NewReader("static\n"), &bytes.Buffer{}, true); err == nil {
+		t.Fatal("confirmWith: want error typing the overridden Confirm value, got nil")
+	}
+}
+
+func TestConfirmWithPlainYesNoPrompt(t *testing.T) {
+	cases := []struct {
+		name    string
+		input   string
+		wantErr bool
+	}{
+		{name: "y accepts", input: "y\n", wantErr: false},
+		{name: "yes accepts", input: "yes\n", wantErr: false},
+		{name: "YES accepts case-insensitively", input: "YES\n", wantErr: false},
+		{name: "n aborts", input: "n\n", wantErr: true},
+		{name: "empty aborts", input: "\n", wantErr: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := testContext(t, nil)
+			err := confirmWith(c, "rollback", Rule{}, strings.NewReader(tc.input), &bytes.Buffer{}, true)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("confirmWith(%q) error = %v, wantErr %v", tc.input, err, tc.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,35 @@
+// Package action adapts a command's typed business logic into a
+// cli.ActionFunc, following the GoToSocial internal/action pattern: the
+// command package stays free of boilerplate dependency lookups and
+// panic recovery, and every command gets both for free.
+package action
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/vanman2024/cli-builder/plugins/cli-builder/skills/cli-patterns/examples/context-demo/internal/appctx"
+	"github.com/vanman2024/cli-builder/plugins/cli-builder/skills/cli-patterns/examples/context-demo/internal/config"
+)
+
+// Func is a command's core logic, once its dependencies are resolved.
+type Func func(ctx context.Context, cfg *config.Config, db *sql.DB) error
+
+// Wrap adapts fn into a cli.ActionFunc bound to deps: it supplies fn
+// with the already-booted Config and DB, and recovers any panic from fn
+// as a cli.Exit (exit code 1) so a bug in one command can't take down
+// the whole process.
+func Wrap(deps *appctx.AppContext, fn Func) cli.ActionFunc {
+	return func(c *cli.Context) (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = cli.Exit(fmt.Sprintf("panic: %v", r), 1)
+			}
+		}()
+
+		return fn(c.Context, deps.Config(), deps.DB())
+	}
+}
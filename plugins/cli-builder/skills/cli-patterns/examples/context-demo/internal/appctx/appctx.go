@@ -0,0 +1,48 @@
+// Package appctx defines AppContext, the app's shared state resolved
+// once at boot by a boot.Booter. It is constructed zero-valued before
+// the App's Commands are built (see internal/command), then bound once
+// the boot tasks have run; command Actions close over the same pointer
+// so they always see the resolved state by the time they execute.
+package appctx
+
+import (
+	"database/sql"
+	"log/slog"
+
+	"github.com/vanman2024/cli-builder/plugins/cli-builder/skills/cli-patterns/examples/context-demo/internal/boot"
+	"github.com/vanman2024/cli-builder/plugins/cli-builder/skills/cli-patterns/examples/context-demo/internal/config"
+	"github.com/vanman2024/cli-builder/plugins/cli-builder/skills/cli-patterns/examples/context-demo/internal/db"
+	"github.com/vanman2024/cli-builder/plugins/cli-builder/skills/cli-patterns/examples/context-demo/internal/logger"
+)
+
+// AppContext holds shared state across commands: the resolved Config,
+// Logger, and DB connection, plus the Booter that tore them down.
+type AppContext struct {
+	booter     *boot.Booter
+	configTask *config.Task
+	loggerTask *logger.Task
+	dbTask     *db.Task
+}
+
+// Bind records the boot tasks once Booter.Boot has completed, making
+// Config/Logger/DB/Shutdown valid.
+func (a *AppContext) Bind(booter *boot.Booter, configTask *config.Task, loggerTask *logger.Task, dbTask *db.Task) {
+	a.booter = booter
+	a.configTask = configTask
+	a.loggerTask = loggerTask
+	a.dbTask = dbTask
+}
+
+func (a *AppContext) Config() *config.Config { return a.configTask.Config() }
+func (a *AppContext) Logger() *slog.Logger   { return a.loggerTask.Logger() }
+func (a *AppContext) DB() *sql.DB            { return a.dbTask.DB() }
+
+// Shutdown tears tasks down in reverse boot order via the Booter. It is
+// a no-op if Bind was never called, e.g. when Before failed before
+// booting completed.
+func (a *AppContext) Shutdown() error {
+	if a.booter == nil {
+		return nil
+	}
+	return a.booter.Shutdown()
+}
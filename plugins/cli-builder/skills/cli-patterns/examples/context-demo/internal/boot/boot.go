@@ -0,0 +1,134 @@
+// Package boot implements a small task/dependency orchestrator for
+// context-demo's shared resources (config, logger, DB), modeled on
+// Arvados' boot task pattern: independent tasks run concurrently,
+// dependent tasks wait on their dependencies, and the whole graph is
+// canceled on the first failure.
+package boot
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// Task is one bootable unit of shared state. Run performs setup and
+// returns once the task is ready; fail lets a task report a failure
+// that surfaces asynchronously, after Run has already returned (e.g. a
+// background connection that later drops).
+type Task interface {
+	Run(ctx context.Context, fail func(error)) error
+	String() string
+}
+
+type node struct {
+	task      Task
+	dependsOn []string
+	done      chan struct{}
+	err       error
+}
+
+// Booter resolves a set of Tasks in dependency order, runs tasks with no
+// unmet dependency concurrently, and cancels the whole graph on the
+// first failure.
+type Booter struct {
+	nodes map[string]*node
+	order []string
+}
+
+// NewBooter returns an empty Booter ready for Add calls.
+func NewBooter() *Booter {
+	return &Booter{nodes: map[string]*node{}}
+}
+
+// Add registers task, which will not run until every task named in
+// dependsOn has completed successfully. The order of Add calls does not
+// matter; Boot resolves the dependency graph itself.
+func (b *Booter) Add(task Task, dependsOn ...string) {
+	name := task.String()
+	b.nodes[name] = &node{task: task, dependsOn: dependsOn, done: make(chan struct{})}
+	b.order = append(b.order, name)
+}
+
+// Boot runs every registered task, blocking until all have either
+// completed or the graph has been canceled by a failure. It returns the
+// first error encountered, if any.
+func (b *Booter) Boot(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		mu      sync.Mutex
+		failErr error
+	)
+	fail := func(err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if failErr == nil {
+			failErr = err
+			cancel()
+		}
+	}
+
+	var wg sync.WaitGroup
+	for _, name := range b.order {
+		n := b.nodes[name]
+		wg.Add(1)
+		go func(n *node) {
+			defer wg.Done()
+			defer close(n.done)
+			n.err = b.run(ctx, n, fail)
+		}(n)
+	}
+	wg.Wait()
+
+	return failErr
+}
+
+func (b *Booter) run(ctx context.Context, n *node, fail func(error)) error {
+	for _, dep := range n.dependsOn {
+		depNode, ok := b.nodes[dep]
+		if !ok {
+			err := fmt.Errorf("boot: %s depends on unregistered task %q", n.task, dep)
+			fail(err)
+			return err
+		}
+		select {
+		case <-depNode.done:
+			if depNode.err != nil {
+				err := fmt.Errorf("boot: %s: dependency %q failed: %w", n.task, dep, depNode.err)
+				fail(err)
+				return err
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if err := n.task.Run(ctx, fail); err != nil {
+		fail(err)
+		return err
+	}
+	return nil
+}
+
+// Shutdown tears tasks down in reverse registration order, not in
+// dependency order: a dependent is closed before what it depended on
+// only if it was added after it. Tasks implementing
+// io.Closer are closed; shutdown continues past individual errors,
+// returning the last one encountered.
+func (b *Booter) Shutdown() error {
+	var last error
+	for i := len(b.order) - 1; i >= 0; i-- {
+		if closer, ok := b.nodes[b.order[i]].task.(io.Closer); ok {
+			if err := closer.Close(); err != nil {
+				last = err
+			}
+		}
+	}
+	return last
+}
@@ -0,0 +1,138 @@
+package boot
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeTask is a minimal Task whose Run behavior is supplied by the test.
+type fakeTask struct {
+	name string
+	run  func(ctx context.Context, fail func(error)) error
+}
+
+func (f *fakeTask) Run(ctx context.Context, fail func(error)) error { return f.run(ctx, fail) }
+func (f *fakeTask) String() string                                 { return f.name }
+
+func TestBootWaitsForDependencies(t *testing.T) {
+	var (
+		mu    sync.Mutex
+		order []string
+	)
+	record := func(name string) {
+		mu.Lock()
+		defer mu.Unlock()
+		order = append(order, name)
+	}
+
+	b := NewBooter()
+	b.Add(&fakeTask{name: "db", run: func(ctx context.Context, fail func(error)) error {
+		time.Sleep(10 * time.Millisecond)
+		record("db")
+		return nil
+	}})
+	b.Add(&fakeTask{name: "server", run: func(ctx context.Context, fail func(error)) error {
+		record("server")
+		return nil
+	}}, "db")
+
+	if err := b.Boot(context.Background()); err != nil {
+		t.Fatalf("Boot: %v", err)
+	}
+
+	if len(order) != 2 || order[0] != "db" || order[1] != "server" {
+		t.Fatalf("run order = %v, want [db server]", order)
+	}
+}
+
+func TestBootCancelsOnFailure(t *testing.T) {
+	boomErr := errors.New("boom")
+
+	b := NewBooter()
+	b.Add(&fakeTask{name: "fails", run: func(ctx context.Context, fail func(error)) error {
+		return boomErr
+	}})
+
+	canceled := make(chan struct{})
+	b.Add(&fakeTask{name: "unrelated", run: func(ctx context.Context, fail func(error)) error {
+		select {
+		case <-ctx.Done():
+			close(canceled)
+		case <-time.After(time.Second):
+		}
+		return ctx.Err()
+	}})
+
+	err := b.Boot(context.Background())
+	if !errors.Is(err, boomErr) {
+		t.Fatalf("Boot() error = %v, want %v", err, boomErr)
+	}
+	select {
+	case <-canceled:
+	case <-time.After(time.Second):
+		t.Fatal("unrelated task was never canceled after the sibling failure")
+	}
+}
+
+func TestBootDependentFailsWhenDependencyFails(t *testing.T) {
+	boomErr := errors.New("boom")
+
+	b := NewBooter()
+	b.Add(&fakeTask{name: "db", run: func(ctx context.Context, fail func(error)) error {
+		return boomErr
+	}})
+	b.Add(&fakeTask{name: "server", run: func(ctx context.Context, fail func(error)) error {
+		t.Error("server.Run should not run when its dependency failed")
+		return nil
+	}}, "db")
+
+	if err := b.Boot(context.Background()); !errors.Is(err, boomErr) {
+		t.Fatalf("Boot() error = %v, want %v", err, boomErr)
+	}
+}
+
+func TestBootUnregisteredDependency(t *testing.T) {
+	b := NewBooter()
+	b.Add(&fakeTask{name: "server", run: func(ctx context.Context, fail func(error)) error {
+		return nil
+	}}, "missing")
+
+	if err := b.Boot(context.Background()); err == nil {
+		t.Fatal("Boot: want error for unregistered dependency, got nil")
+	}
+}
+
+// closingTask additionally records itself in closed when Close is called,
+// so Shutdown's ordering can be asserted.
+type closingTask struct {
+	fakeTask
+	closed *[]string
+}
+
+func (c *closingTask) Close() error {
+	*c.closed = append(*c.closed, c.name)
+	return nil
+}
+
+func TestShutdownClosesInReverseOrder(t *testing.T) {
+	var closed []string
+	noop := func(ctx context.Context, fail func(error)) error { return nil }
+
+	b := NewBooter()
+	b.Add(&closingTask{fakeTask: fakeTask{name: "db", run: noop}, closed: &closed})
+	b.Add(&closingTask{fakeTask: fakeTask{name: "server", run: noop}, closed: &closed}, "db")
+
+	if err := b.Boot(context.Background()); err != nil {
+		t.Fatalf("Boot: %v", err)
+	}
+	if err := b.Shutdown(); err != nil {
+		t.Fatalf("Shutdown: %v", err)
+	}
+
+	if len(closed) != 2 || closed[0] != "server" || closed[1] != "db" {
+		t.Fatalf("Shutdown order = %v, want [server db]", closed)
+	}
+}
@@ -0,0 +1,163 @@
+package command
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/vanman2024/cli-builder/plugins/cli-builder/skills/cli-patterns/examples/context-demo/internal/action"
+	"github.com/vanman2024/cli-builder/plugins/cli-builder/skills/cli-patterns/examples/context-demo/internal/appctx"
+	"github.com/vanman2024/cli-builder/plugins/cli-builder/skills/cli-patterns/examples/context-demo/internal/config"
+	"github.com/vanman2024/cli-builder/plugins/cli-builder/skills/cli-patterns/examples/context-demo/internal/migrate"
+)
+
+func init() { Register(NewMigrateCmd) }
+
+// NewMigrateCmd builds the "migrate" command and its up/down/version/
+// force/goto/create subcommands, all backed by internal/migrate.
+func NewMigrateCmd(deps *appctx.AppContext) *cli.Command {
+	return &cli.Command{
+		Name:  "migrate",
+		Usage: "Manage database schema migrations",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "migrations-dir",
+				Usage: "Directory holding .up.sql/.down.sql migration files",
+				Value: "./db/migrations",
+			},
+			&cli.BoolFlag{
+				Name:  "dry-run",
+				Usage: "Print planned migrations instead of applying them",
+			},
+		},
+		Subcommands: []*cli.Command{
+			{
+				Name:      "up",
+				Usage:     "Apply pending migrations",
+				ArgsUsage: "[N]",
+				Action: func(c *cli.Context) error {
+					dir, dryRun := c.String("migrations-dir"), c.Bool("dry-run")
+					steps, err := optionalSteps(c)
+					if err != nil {
+						return err
+					}
+
+					return action.Wrap(deps, func(ctx context.Context, cfg *config.Config, db *sql.DB) error {
+						deps.Logger().Debug("migrate up", "steps", steps, "driver", cfg.Driver, "dir", dir, "dry_run", dryRun)
+						return migrate.Up(db, cfg.Driver, dir, steps, dryRun, deps.Logger())
+					})(c)
+				},
+			},
+			{
+				Name:      "down",
+				Usage:     "Roll back applied migrations",
+				ArgsUsage: "[N]",
+				Action: func(c *cli.Context) error {
+					dir, dryRun := c.String("migrations-dir"), c.Bool("dry-run")
+					steps, err := optionalSteps(c)
+					if err != nil {
+						return err
+					}
+
+					return action.Wrap(deps, func(ctx context.Context, cfg *config.Config, db *sql.DB) error {
+						deps.Logger().Debug("migrate down", "steps", steps, "driver", cfg.Driver, "dir", dir, "dry_run", dryRun)
+						return migrate.Down(db, cfg.Driver, dir, steps, dryRun, deps.Logger())
+					})(c)
+				},
+			},
+			{
+				Name:  "version",
+				Usage: "Print the current schema version",
+				Action: func(c *cli.Context) error {
+					dir := c.String("migrations-dir")
+
+					return action.Wrap(deps, func(ctx context.Context, cfg *config.Config, db *sql.DB) error {
+						deps.Logger().Debug("migrate version", "driver", cfg.Driver, "dir", dir)
+						v, dirty, err := migrate.Version(db, cfg.Driver, dir)
+						if err != nil {
+							return err
+						}
+
+						fmt.Printf("Version: %d (dirty: %t)\n", v, dirty)
+						return nil
+					})(c)
+				},
+			},
+			{
+				Name:      "force",
+				Usage:     "Force the schema version without running migrations",
+				ArgsUsage: "<version>",
+				Action: func(c *cli.Context) error {
+					dir := c.String("migrations-dir")
+					version, err := strconv.Atoi(c.Args().First())
+					if err != nil {
+						return fmt.Errorf("migrate force: invalid version %q: %w", c.Args().First(), err)
+					}
+
+					return action.Wrap(deps, func(ctx context.Context, cfg *config.Config, db *sql.DB) error {
+						deps.Logger().Debug("migrate force", "version", version, "driver", cfg.Driver, "dir", dir)
+						return migrate.Force(db, cfg.Driver, dir, version)
+					})(c)
+				},
+			},
+			{
+				Name:      "goto",
+				Usage:     "Migrate up or down to an exact version",
+				ArgsUsage: "<version>",
+				Action: func(c *cli.Context) error {
+					dir := c.String("migrations-dir")
+					version, err := strconv.ParseUint(c.Args().First(), 10, 64)
+					if err != nil {
+						return fmt.Errorf("migrate goto: invalid version %q: %w", c.Args().First(), err)
+					}
+
+					return action.Wrap(deps, func(ctx context.Context, cfg *config.Config, db *sql.DB) error {
+						deps.Logger().Debug("migrate goto", "version", version, "driver", cfg.Driver, "dir", dir)
+						return migrate.Goto(db, cfg.Driver, dir, uint(version))
+					})(c)
+				},
+			},
+			{
+				Name:      "create",
+				Usage:     "Scaffold a new pair of up/down migration files",
+				ArgsUsage: "<name>",
+				Action: func(c *cli.Context) error {
+					dir := c.String("migrations-dir")
+					name := c.Args().First()
+					if name == "" {
+						return fmt.Errorf("migrate create: name is required")
+					}
+
+					return action.Wrap(deps, func(ctx context.Context, cfg *config.Config, db *sql.DB) error {
+						up, down, err := migrate.Create(dir, name)
+						if err != nil {
+							return err
+						}
+
+						deps.Logger().Debug("migrate create", "up", up, "down", down)
+						fmt.Printf("Created %s\n%s\n", up, down)
+						return nil
+					})(c)
+				},
+			},
+		},
+	}
+}
+
+// optionalSteps parses the optional leading positional "N" argument
+// accepted by "migrate up"/"migrate down", defaulting to 0 (all pending
+// migrations) when no argument was given.
+func optionalSteps(c *cli.Context) (int, error) {
+	arg := c.Args().First()
+	if arg == "" {
+		return 0, nil
+	}
+	steps, err := strconv.Atoi(arg)
+	if err != nil {
+		return 0, fmt.Errorf("migrate %s: invalid step count %q: %w", c.Command.Name, arg, err)
+	}
+	return steps, nil
+}
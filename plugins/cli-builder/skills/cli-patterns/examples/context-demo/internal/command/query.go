@@ -0,0 +1,32 @@
+package command
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/vanman2024/cli-builder/plugins/cli-builder/skills/cli-patterns/examples/context-demo/internal/action"
+	"github.com/vanman2024/cli-builder/plugins/cli-builder/skills/cli-patterns/examples/context-demo/internal/appctx"
+	"github.com/vanman2024/cli-builder/plugins/cli-builder/skills/cli-patterns/examples/context-demo/internal/config"
+)
+
+func init() { Register(NewQueryCmd) }
+
+// NewQueryCmd builds the "query" command, which executes a database
+// query over the resolved DB connection.
+func NewQueryCmd(deps *appctx.AppContext) *cli.Command {
+	return &cli.Command{
+		Name:  "query",
+		Usage: "Execute a database query",
+		Action: action.Wrap(deps, func(ctx context.Context, cfg *config.Config, db *sql.DB) error {
+			deps.Logger().Debug("connecting", "host", cfg.Host, "port", cfg.Port, "database", cfg.Database)
+
+			fmt.Println("Executing query...")
+			// Use db for actual query
+
+			return nil
+		}),
+	}
+}
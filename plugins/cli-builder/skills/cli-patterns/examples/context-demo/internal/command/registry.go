@@ -0,0 +1,32 @@
+// Package command holds one file per context-demo subcommand. Each
+// file registers its constructor from its own init(), so adding a new
+// subcommand never requires editing main.go: drop in a new file that
+// calls Register, and Commands picks it up automatically.
+package command
+
+import (
+	"github.com/urfave/cli/v2"
+
+	"github.com/vanman2024/cli-builder/plugins/cli-builder/skills/cli-patterns/examples/context-demo/internal/appctx"
+)
+
+// Constructor builds a *cli.Command from the app's shared AppContext.
+type Constructor func(deps *appctx.AppContext) *cli.Command
+
+var registry []Constructor
+
+// Register adds a command constructor to the registry. Call it from a
+// package-level init() in the file that defines the command.
+func Register(c Constructor) {
+	registry = append(registry, c)
+}
+
+// Commands builds every registered command against deps, in
+// registration order, for main.go's cli.App.Commands.
+func Commands(deps *appctx.AppContext) []*cli.Command {
+	cmds := make([]*cli.Command, 0, len(registry))
+	for _, c := range registry {
+		cmds = append(cmds, c(deps))
+	}
+	return cmds
+}
@@ -0,0 +1,46 @@
+package command
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/vanman2024/cli-builder/plugins/cli-builder/skills/cli-patterns/examples/context-demo/internal/action"
+	"github.com/vanman2024/cli-builder/plugins/cli-builder/skills/cli-patterns/examples/context-demo/internal/appctx"
+	"github.com/vanman2024/cli-builder/plugins/cli-builder/skills/cli-patterns/examples/context-demo/internal/config"
+)
+
+func init() { Register(NewStatusCmd) }
+
+// NewStatusCmd builds the "status" command, which reports the resolved
+// connection settings.
+func NewStatusCmd(deps *appctx.AppContext) *cli.Command {
+	return &cli.Command{
+		Name:  "status",
+		Usage: "Check database status",
+		Action: action.Wrap(deps, func(ctx context.Context, cfg *config.Config, db *sql.DB) error {
+			fmt.Printf("Database: %s\n", cfg.Database)
+			fmt.Printf("Host: %s:%d\n", cfg.Host, cfg.Port)
+			fmt.Printf("Driver: %s\n", cfg.Driver)
+
+			switch cfg.Driver {
+			case "sqlite":
+				fmt.Printf("SQLite tuning: journal_mode=%s synchronous=%s cache_size=%d busy_timeout=%d foreign_keys=%t mmap_size=%d\n",
+					cfg.SQLite.JournalMode, cfg.SQLite.Synchronous, cfg.SQLite.CacheSize, cfg.SQLite.BusyTimeout, cfg.SQLite.ForeignKeys, cfg.SQLite.MMapSize)
+			case "postgres":
+				fmt.Printf("Postgres tuning: max_open_conns=%d max_idle_conns=%d conn_max_lifetime=%s sslmode=%s\n",
+					cfg.Postgres.MaxOpenConns, cfg.Postgres.MaxIdleConns, cfg.Postgres.ConnMaxLifetime, cfg.Postgres.SSLMode)
+			}
+
+			fmt.Println("Status: Connected")
+
+			if cfg.Verbose {
+				fmt.Println("Verbose mode: enabled")
+			}
+
+			return nil
+		}),
+	}
+}
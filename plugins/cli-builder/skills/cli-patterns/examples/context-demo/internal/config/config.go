@@ -0,0 +1,191 @@
+// Package config centralizes context-demo's settings so each one is
+// declared exactly once: FlagNames/EnvNames name the cli.Flag and
+// environment variable that can override it, and Task.Run layers them
+// over a --config YAML file in precedence order (flags > env > file >
+// default), mirroring the pattern GoToSocial uses for its own config
+// package.
+package config
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/urfave/cli/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// FlagNames holds the cli.Flag name backing each setting.
+type FlagNames struct {
+	Verbose    string
+	ConfigPath string
+	Host       string
+	Port       string
+	Database   string
+	Driver     string
+}
+
+// Flags is the canonical set of flag names main.go's cli.App.Flags must
+// declare; every other layer (env, file) overrides the same names.
+var Flags = FlagNames{
+	Verbose:    "verbose",
+	ConfigPath: "config",
+	Host:       "host",
+	Port:       "port",
+	Database:   "database",
+	Driver:     "driver",
+}
+
+// EnvNames holds the environment variable backing each setting that
+// supports one. ConfigPath, Verbose and Driver are flag-only.
+type EnvNames struct {
+	Host     string
+	Port     string
+	Database string
+}
+
+// Env is the canonical set of environment variable names resolve reads.
+var Env = EnvNames{
+	Host:     "CONTEXT_DEMO_HOST",
+	Port:     "CONTEXT_DEMO_PORT",
+	Database: "CONTEXT_DEMO_DATABASE",
+}
+
+// SQLiteTuning holds the per-connection PRAGMAs db.Open applies when
+// Config.Driver is "sqlite", mirroring GoToSocial's sqlite-optimizations
+// branch.
+type SQLiteTuning struct {
+	JournalMode string `yaml:"journal_mode"`
+	Synchronous string `yaml:"synchronous"`
+	CacheSize   int    `yaml:"cache_size"`
+	BusyTimeout int    `yaml:"busy_timeout"`
+	ForeignKeys bool   `yaml:"foreign_keys"`
+	MMapSize    int64  `yaml:"mmap_size"`
+}
+
+// PostgresTuning holds the connection-pool settings db.Open applies
+// when Config.Driver is "postgres".
+type PostgresTuning struct {
+	MaxOpenConns    int           `yaml:"max_open_conns"`
+	MaxIdleConns    int           `yaml:"max_idle_conns"`
+	ConnMaxLifetime time.Duration `yaml:"conn_max_lifetime"`
+	SSLMode         string        `yaml:"sslmode"`
+}
+
+// Config is the app's fully resolved, strongly-typed settings.
+type Config struct {
+	Host     string         `yaml:"host"`
+	Port     int            `yaml:"port"`
+	Database string         `yaml:"database"`
+	Driver   string         `yaml:"driver"`
+	SQLite   SQLiteTuning   `yaml:"sqlite"`
+	Postgres PostgresTuning `yaml:"postgres"`
+	Verbose  bool           `yaml:"-"`
+}
+
+func defaults() Config {
+	return Config{
+		Host:     "localhost",
+		Port:     5432,
+		Database: "mydb",
+		Driver:   "postgres",
+		SQLite: SQLiteTuning{
+			JournalMode: "WAL",
+			Synchronous: "NORMAL",
+			CacheSize:   -2000,
+			BusyTimeout: 5000,
+			ForeignKeys: true,
+			MMapSize:    134217728,
+		},
+		Postgres: PostgresTuning{
+			MaxOpenConns:    10,
+			MaxIdleConns:    5,
+			ConnMaxLifetime: 30 * time.Minute,
+			SSLMode:         "disable",
+		},
+	}
+}
+
+// Task resolves the app's Config as a boot.Task: it depends on nothing,
+// and its Config accessor is what AppContext.Config reads once booted.
+type Task struct {
+	c   *cli.Context
+	cfg *Config
+}
+
+// NewTask returns a config boot.Task that resolves c's flags/env/file
+// into a *Config when run.
+func NewTask(c *cli.Context) *Task {
+	return &Task{c: c}
+}
+
+func (t *Task) String() string { return "config" }
+
+// Run resolves the config and stores it for Config to return. It never
+// calls fail itself; resolve errors are returned directly so the Booter
+// can report them at boot time.
+func (t *Task) Run(ctx context.Context, fail func(error)) error {
+	cfg, err := resolve(t.c)
+	if err != nil {
+		return err
+	}
+	t.cfg = cfg
+	return nil
+}
+
+// Config returns the resolved Config. It is only valid after Run has
+// completed successfully.
+func (t *Task) Config() *Config { return t.cfg }
+
+// resolve builds this run's Config: it starts from defaults(), overlays
+// the --config YAML file (if one exists), then environment variables,
+// then whatever flags were explicitly set on c.
+func resolve(c *cli.Context) (*Config, error) {
+	cfg := defaults()
+
+	if path := c.String(Flags.ConfigPath); path != "" {
+		data, err := os.ReadFile(path)
+		switch {
+		case err == nil:
+			if err := yaml.Unmarshal(data, &cfg); err != nil {
+				return nil, fmt.Errorf("config: parsing %s: %w", path, err)
+			}
+		case os.IsNotExist(err):
+			// No file at the default path is fine; env/flags still apply.
+		default:
+			return nil, fmt.Errorf("config: reading %s: %w", path, err)
+		}
+	}
+
+	if v := os.Getenv(Env.Host); v != "" {
+		cfg.Host = v
+	}
+	if v := os.Getenv(Env.Port); v != "" {
+		port, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, fmt.Errorf("config: %s=%q: %w", Env.Port, v, err)
+		}
+		cfg.Port = port
+	}
+	if v := os.Getenv(Env.Database); v != "" {
+		cfg.Database = v
+	}
+
+	if c.IsSet(Flags.Host) {
+		cfg.Host = c.String(Flags.Host)
+	}
+	if c.IsSet(Flags.Port) {
+		cfg.Port = c.Int(Flags.Port)
+	}
+	if c.IsSet(Flags.Database) {
+		cfg.Database = c.String(Flags.Database)
+	}
+	if c.IsSet(Flags.Driver) {
+		cfg.Driver = c.String(Flags.Driver)
+	}
+	cfg.Verbose = c.Bool(Flags.Verbose)
+
+	return &cfg, nil
+}
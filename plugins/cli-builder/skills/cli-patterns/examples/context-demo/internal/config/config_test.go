@@ -0,0 +1,122 @@
+package config
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/urfave/cli/v2"
+)
+
+// testContext builds a *cli.Context with Flags' names registered, setting
+// set to the given string values and marking them as explicitly set on c
+// (mirroring what urfave/cli does for a flag passed on argv).
+func testContext(t *testing.T, configPath string, set map[string]string) *cli.Context {
+	t.Helper()
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.String(Flags.ConfigPath, "", "")
+	fs.String(Flags.Host, "", "")
+	fs.Int(Flags.Port, 0, "")
+	fs.String(Flags.Database, "", "")
+	fs.String(Flags.Driver, "", "")
+	fs.Bool(Flags.Verbose, false, "")
+
+	if configPath != "" {
+		if err := fs.Set(Flags.ConfigPath, configPath); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for name, v := range set {
+		if err := fs.Set(name, v); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return cli.NewContext(cli.NewApp(), fs, nil)
+}
+
+func TestResolveDefaults(t *testing.T) {
+	cfg, err := resolve(testContext(t, "", nil))
+	if err != nil {
+		t.Fatalf("resolve: %v", err)
+	}
+	want := defaults()
+	if *cfg != want {
+		t.Fatalf("resolve() = %+v, want defaults %+v", *cfg, want)
+	}
+}
+
+func TestResolveFileOverridesDefaults(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "context-demo.yaml")
+	if err := os.WriteFile(path, []byte("host: file-host\nport: 1111\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := resolve(testContext(t, path, nil))
+	if err != nil {
+		t.Fatalf("resolve: %v", err)
+	}
+	if cfg.Host != "file-host" || cfg.Port != 1111 {
+		t.Fatalf("resolve() = %+v, want file's host/port to apply", *cfg)
+	}
+	// A field the file didn't set keeps its default.
+	if cfg.Database != defaults().Database {
+		t.Fatalf("resolve().Database = %q, want default %q", cfg.Database, defaults().Database)
+	}
+}
+
+func TestResolveMissingFileIsFine(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "missing.yaml")
+	cfg, err := resolve(testContext(t, path, nil))
+	if err != nil {
+		t.Fatalf("resolve: %v", err)
+	}
+	if cfg.Host != defaults().Host {
+		t.Fatalf("resolve() with a missing --config file should fall back to defaults, got %+v", *cfg)
+	}
+}
+
+func TestResolveEnvOverridesFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "context-demo.yaml")
+	if err := os.WriteFile(path, []byte("host: file-host\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(Env.Host, "env-host")
+
+	cfg, err := resolve(testContext(t, path, nil))
+	if err != nil {
+		t.Fatalf("resolve: %v", err)
+	}
+	if cfg.Host != "env-host" {
+		t.Fatalf("resolve().Host = %q, want env var to win over the file", cfg.Host)
+	}
+}
+
+func TestResolveFlagOverridesEnv(t *testing.T) {
+	t.Setenv(Env.Host, "env-host")
+
+	cfg, err := resolve(testContext(t, "", map[string]string{Flags.Host: "flag-host"}))
+	if err != nil {
+		t.Fatalf("resolve: %v", err)
+	}
+	if cfg.Host != "flag-host" {
+		t.Fatalf("resolve().Host = %q, want flag to win over the env var", cfg.Host)
+	}
+}
+
+func TestResolveInvalidEnvPort(t *testing.T) {
+	t.Setenv(Env.Port, "not-a-number")
+	if _, err := resolve(testContext(t, "", nil)); err == nil {
+		t.Fatal("resolve: want error for a non-numeric port env var, got nil")
+	}
+}
+
+func TestResolveInvalidFileYAML(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "context-demo.yaml")
+	if err := os.WriteFile(path, []byte("host: [unterminated\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := resolve(testContext(t, path, nil)); err == nil {
+		t.Fatal("resolve: want error for unparsable YAML, got nil")
+	}
+}
@@ -0,0 +1,145 @@
+// Package db provides context-demo's driver-agnostic DB-open boot task:
+// Open selects postgres or sqlite from config.Config.Driver, applies
+// that driver's pool/PRAGMA tuning, and Task.Run pings the resulting
+// connection with retry/backoff so the rest of the app never has to
+// deal with a not-yet-ready database.
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/mattn/go-sqlite3"
+
+	_ "github.com/lib/pq"
+
+	"github.com/vanman2024/cli-builder/plugins/cli-builder/skills/cli-patterns/examples/context-demo/internal/config"
+)
+
+// sqliteDriverName is the database/sql driver name Open registers once,
+// with a ConnectHook that applies Config.SQLite's PRAGMAs to every new
+// connection, mirroring GoToSocial's sqlite-optimizations branch.
+const sqliteDriverName = "sqlite3_context_demo"
+
+var registerSQLiteOnce sync.Once
+
+func registerSQLiteDriver(tuning config.SQLiteTuning) {
+	registerSQLiteOnce.Do(func() {
+		sql.Register(sqliteDriverName, &sqlite3.SQLiteDriver{
+			ConnectHook: func(conn *sqlite3.SQLiteConn) error {
+				pragmas := []string{
+					fmt.Sprintf("PRAGMA journal_mode=%s", tuning.JournalMode),
+					fmt.Sprintf("PRAGMA synchronous=%s", tuning.Synchronous),
+					fmt.Sprintf("PRAGMA cache_size=%d", tuning.CacheSize),
+					fmt.Sprintf("PRAGMA busy_timeout=%d", tuning.BusyTimeout),
+					fmt.Sprintf("PRAGMA foreign_keys=%s", onOff(tuning.ForeignKeys)),
+					fmt.Sprintf("PRAGMA mmap_size=%d", tuning.MMapSize),
+				}
+				for _, pragma := range pragmas {
+					if _, err := conn.Exec(pragma, nil); err != nil {
+						return fmt.Errorf("db: applying %q: %w", pragma, err)
+					}
+				}
+				return nil
+			},
+		})
+	})
+}
+
+func onOff(b bool) string {
+	if b {
+		return "ON"
+	}
+	return "OFF"
+}
+
+// Open opens *sql.DB for cfg.Driver ("postgres" or "sqlite"), applying
+// that driver's tuning from cfg.
+func Open(cfg *config.Config) (*sql.DB, error) {
+	switch cfg.Driver {
+	case "sqlite":
+		registerSQLiteDriver(cfg.SQLite)
+
+		db, err := sql.Open(sqliteDriverName, cfg.Database)
+		if err != nil {
+			return nil, fmt.Errorf("db: opening sqlite %s: %w", cfg.Database, err)
+		}
+		return db, nil
+
+	case "postgres":
+		dsn := fmt.Sprintf("host=%s port=%d dbname=%s sslmode=%s", cfg.Host, cfg.Port, cfg.Database, cfg.Postgres.SSLMode)
+		db, err := sql.Open("postgres", dsn)
+		if err != nil {
+			return nil, fmt.Errorf("db: opening postgres %s:%d/%s: %w", cfg.Host, cfg.Port, cfg.Database, err)
+		}
+		db.SetMaxOpenConns(cfg.Postgres.MaxOpenConns)
+		db.SetMaxIdleConns(cfg.Postgres.MaxIdleConns)
+		db.SetConnMaxLifetime(cfg.Postgres.ConnMaxLifetime)
+		return db, nil
+
+	default:
+		return nil, fmt.Errorf("db: unsupported driver %q", cfg.Driver)
+	}
+}
+
+// Task opens *sql.DB as a boot.Task, once its "config" dependency has
+// resolved the connection settings, retrying PingContext with
+// exponential backoff until it succeeds or MaxAttempts is exhausted.
+type Task struct {
+	configTask *config.Task
+
+	MaxAttempts int
+	BaseDelay   time.Duration
+
+	db *sql.DB
+}
+
+// NewTask returns a DB boot.Task with sensible retry defaults, depending
+// on configTask having already run.
+func NewTask(configTask *config.Task) *Task {
+	return &Task{configTask: configTask, MaxAttempts: 5, BaseDelay: 200 * time.Millisecond}
+}
+
+func (t *Task) String() string { return "db" }
+
+func (t *Task) Run(ctx context.Context, fail func(error)) error {
+	cfg := t.configTask.Config()
+
+	db, err := Open(cfg)
+	if err != nil {
+		return err
+	}
+
+	var pingErr error
+	for attempt := 0; attempt < t.MaxAttempts; attempt++ {
+		if pingErr = db.PingContext(ctx); pingErr == nil {
+			break
+		}
+		select {
+		case <-time.After(t.BaseDelay << attempt):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	if pingErr != nil {
+		return fmt.Errorf("db: pinging %s (%s driver) after %d attempts: %w", cfg.Database, cfg.Driver, t.MaxAttempts, pingErr)
+	}
+
+	t.db = db
+	return nil
+}
+
+// DB returns the opened connection pool. It is only valid after Run has
+// completed successfully.
+func (t *Task) DB() *sql.DB { return t.db }
+
+// Close implements io.Closer so Booter.Shutdown closes the pool.
+func (t *Task) Close() error {
+	if t.db == nil {
+		return nil
+	}
+	return t.db.Close()
+}
@@ -0,0 +1,38 @@
+// Package logger provides context-demo's logger-init boot task.
+package logger
+
+import (
+	"context"
+	"log/slog"
+	"os"
+
+	"github.com/vanman2024/cli-builder/plugins/cli-builder/skills/cli-patterns/examples/context-demo/internal/config"
+)
+
+// Task builds a *slog.Logger as a boot.Task, once its "config"
+// dependency has resolved --verbose.
+type Task struct {
+	configTask *config.Task
+	logger     *slog.Logger
+}
+
+// NewTask returns a logger boot.Task that depends on configTask having
+// already run.
+func NewTask(configTask *config.Task) *Task {
+	return &Task{configTask: configTask}
+}
+
+func (t *Task) String() string { return "logger" }
+
+func (t *Task) Run(ctx context.Context, fail func(error)) error {
+	level := slog.LevelInfo
+	if t.configTask.Config().Verbose {
+		level = slog.LevelDebug
+	}
+	t.logger = slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: level}))
+	return nil
+}
+
+// Logger returns the built logger. It is only valid after Run has
+// completed successfully.
+func (t *Task) Logger() *slog.Logger { return t.logger }
@@ -0,0 +1,246 @@
+// Package migrate wires golang-migrate into context-demo's `migrate`
+// command, selecting a migrate/database driver from the app's
+// config.Config.Driver (postgres or sqlite).
+package migrate
+
+import (
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database"
+	"github.com/golang-migrate/migrate/v4/database/postgres"
+	"github.com/golang-migrate/migrate/v4/database/sqlite3"
+	_ "github.com/golang-migrate/migrate/v4/source/file"
+)
+
+// open builds a *migrate.Migrate over db, using driver ("postgres" or
+// "sqlite") to pick golang-migrate's database backend and dir as the
+// source of ".up.sql"/".down.sql" files.
+func open(db *sql.DB, driver, dir string) (*migrate.Migrate, error) {
+	var (
+		dbDriver database.Driver
+		err      error
+	)
+	switch driver {
+	case "sqlite":
+		dbDriver, err = sqlite3.WithInstance(db, &sqlite3.Config{})
+	case "postgres":
+		dbDriver, err = postgres.WithInstance(db, &postgres.Config{})
+	default:
+		return nil, fmt.Errorf("migrate: unsupported driver %q", driver)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("migrate: preparing %s driver: %w", driver, err)
+	}
+
+	m, err := migrate.NewWithDatabaseInstance("file://"+dir, driver, dbDriver)
+	if err != nil {
+		return nil, fmt.Errorf("migrate: opening migrations in %s: %w", dir, err)
+	}
+	return m, nil
+}
+
+// Up applies up to steps pending migrations (all of them if steps <= 0).
+// With dryRun, it logs the migrations that direction would actually
+// apply instead of running them; golang-migrate has no SQL preview of
+// its own.
+func Up(db *sql.DB, driver, dir string, steps int, dryRun bool, log *slog.Logger) error {
+	if dryRun {
+		return logPlanned(db, driver, dir, steps, true, log)
+	}
+
+	m, err := open(db, driver, dir)
+	if err != nil {
+		return err
+	}
+	defer m.Close()
+
+	if steps > 0 {
+		err = m.Steps(steps)
+	} else {
+		err = m.Up()
+	}
+	return ignoreNoChange(err)
+}
+
+// Down rolls back up to steps applied migrations (all of them if steps
+// <= 0).
+func Down(db *sql.DB, driver, dir string, steps int, dryRun bool, log *slog.Logger) error {
+	if dryRun {
+		return logPlanned(db, driver, dir, steps, false, log)
+	}
+
+	m, err := open(db, driver, dir)
+	if err != nil {
+		return err
+	}
+	defer m.Close()
+
+	if steps > 0 {
+		err = m.Steps(-steps)
+	} else {
+		err = m.Down()
+	}
+	return ignoreNoChange(err)
+}
+
+// Version reports the current schema version and whether it is dirty.
+func Version(db *sql.DB, driver, dir string) (uint, bool, error) {
+	m, err := open(db, driver, dir)
+	if err != nil {
+		return 0, false, err
+	}
+	defer m.Close()
+
+	v, dirty, err := m.Version()
+	if err == migrate.ErrNilVersion {
+		return 0, false, nil
+	}
+	return v, dirty, err
+}
+
+// Force sets the schema version without running any migration, for
+// recovering from a dirty state.
+func Force(db *sql.DB, driver, dir string, version int) error {
+	m, err := open(db, driver, dir)
+	if err != nil {
+		return err
+	}
+	defer m.Close()
+	return m.Force(version)
+}
+
+// Goto migrates (up or down, whichever is needed) to exactly version.
+func Goto(db *sql.DB, driver, dir string, version uint) error {
+	m, err := open(db, driver, dir)
+	if err != nil {
+		return err
+	}
+	defer m.Close()
+	return ignoreNoChange(m.Migrate(version))
+}
+
+// Create writes empty "<timestamp>_<name>.up.sql" and ".down.sql" files
+// to dir, ready for editing.
+func Create(dir, name string) (string, string, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", "", fmt.Errorf("migrate: creating %s: %w", dir, err)
+	}
+
+	stamp := time.Now().UTC().Format("20060102150405")
+	up := filepath.Join(dir, fmt.Sprintf("%s_%s.up.sql", stamp, name))
+	down := filepath.Join(dir, fmt.Sprintf("%s_%s.down.sql", stamp, name))
+
+	for _, path := range []string{up, down} {
+		if err := os.WriteFile(path, []byte("-- TODO\n"), 0o644); err != nil {
+			return "", "", fmt.Errorf("migrate: writing %s: %w", path, err)
+		}
+	}
+	return up, down, nil
+}
+
+// migrationFile describes one parsed "<version>_<name>.<up|down>.sql"
+// file in a migrations directory.
+type migrationFile struct {
+	version uint
+	name    string
+}
+
+// plannedMigrations parses dir's "<version>_<name>.up.sql"/".down.sql"
+// files and returns the ones that direction would actually touch given
+// the schema's current version: up returns files with version > current
+// in ascending order, down returns files with version <= current in
+// descending order. Either list is capped at steps files, if steps > 0.
+func plannedMigrations(dir string, current uint, up bool, steps int) ([]migrationFile, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("migrate: reading %s: %w", dir, err)
+	}
+
+	suffix := ".down.sql"
+	if up {
+		suffix = ".up.sql"
+	}
+
+	var files []migrationFile
+	for _, entry := range entries {
+		name := entry.Name()
+		if !strings.HasSuffix(name, suffix) {
+			continue
+		}
+		versionStr, rest, ok := strings.Cut(name, "_")
+		if !ok {
+			continue
+		}
+		version, err := strconv.ParseUint(versionStr, 10, 64)
+		if err != nil {
+			continue
+		}
+		if up && uint(version) <= current {
+			continue
+		}
+		if !up && uint(version) > current {
+			continue
+		}
+		files = append(files, migrationFile{version: uint(version), name: strings.TrimSuffix(rest, suffix)})
+	}
+
+	sort.Slice(files, func(i, j int) bool {
+		if up {
+			return files[i].version < files[j].version
+		}
+		return files[i].version > files[j].version
+	})
+
+	if steps > 0 && steps < len(files) {
+		files = files[:steps]
+	}
+	return files, nil
+}
+
+// logPlanned logs the migrations that Up (up=true) or Down (up=false)
+// would actually apply from the schema's current version, instead of
+// every file in dir.
+func logPlanned(db *sql.DB, driver, dir string, steps int, up bool, log *slog.Logger) error {
+	m, err := open(db, driver, dir)
+	if err != nil {
+		return err
+	}
+	defer m.Close()
+
+	current, _, err := m.Version()
+	if err == migrate.ErrNilVersion {
+		current = 0
+	} else if err != nil {
+		return err
+	}
+
+	files, err := plannedMigrations(dir, current, up, steps)
+	if err != nil {
+		return err
+	}
+
+	direction := "down"
+	if up {
+		direction = "up"
+	}
+	for _, f := range files {
+		log.Info(fmt.Sprintf("dry-run: would migrate %s", direction), "version", f.version, "file", f.name)
+	}
+	return nil
+}
+
+func ignoreNoChange(err error) error {
+	if err == migrate.ErrNoChange {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,250 @@
+package migrate
+
+import (
+	"database/sql"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func discardLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
+
+func writeMigration(t *testing.T, dir, name, content string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestPlannedMigrationsUp(t *testing.T) {
+	dir := t.TempDir()
+	writeMigration(t, dir, "0001_init.up.sql", "")
+	writeMigration(t, dir, "0001_init.down.sql", "")
+	writeMigration(t, dir, "0002_add_col.up.sql", "")
+	writeMigration(t, dir, "0002_add_col.down.sql", "")
+	writeMigration(t, dir, "0003_add_idx.up.sql", "")
+	writeMigration(t, dir, "0003_add_idx.down.sql", "")
+
+	files, err := plannedMigrations(dir, 1, true, 0)
+	if err != nil {
+		t.Fatalf("plannedMigrations: %v", err)
+	}
+	if len(files) != 2 || files[0].version != 2 || files[1].version != 3 {
+		t.Fatalf("plannedMigrations(up, current=1) = %+v, want versions [2 3] ascending", files)
+	}
+}
+
+func TestPlannedMigrationsDown(t *testing.T) {
+	dir := t.TempDir()
+	writeMigration(t, dir, "0001_init.up.sql", "")
+	writeMigration(t, dir, "0001_init.down.sql", "")
+	writeMigration(t, dir, "0002_add_col.up.sql", "")
+	writeMigration(t, dir, "0002_add_col.down.sql", "")
+	writeMigration(t, dir, "0003_add_idx.up.sql", "")
+	writeMigration(t, dir, "0003_add_idx.down.sql", "")
+
+	files, err := plannedMigrations(dir, 3, false, 0)
+	if err != nil {
+		t.Fatalf("plannedMigrations: %v", err)
+	}
+	if len(files) != 3 || files[0].version != 3 || files[1].version != 2 || files[2].version != 1 {
+		t.Fatalf("plannedMigrations(down, current=3) = %+v, want versions [3 2 1] descending", files)
+	}
+}
+
+func TestPlannedMigrationsStepsCap(t *testing.T) {
+	dir := t.TempDir()
+	writeMigration(t, dir, "0001_init.up.sql", "")
+	writeMigration(t, dir, "0002_add_col.up.sql", "")
+	writeMigration(t, dir, "0003_add_idx.up.sql", "")
+
+	files, err := plannedMigrations(dir, 0, true, 1)
+	if err != nil {
+		t.Fatalf("plannedMigrations: %v", err)
+	}
+	if len(files) != 1 || files[0].version != 1 {
+		t.Fatalf("plannedMigrations(steps=1) = %+v, want just version 1", files)
+	}
+}
+
+func TestPlannedMigrationsIgnoresOtherDirectionAndJunk(t *testing.T) {
+	dir := t.TempDir()
+	writeMigration(t, dir, "0001_init.up.sql", "")
+	writeMigration(t, dir, "0001_init.down.sql", "")
+	writeMigration(t, dir, "not_a_migration.txt", "")
+	writeMigration(t, dir, "nounderscore.up.sql", "")
+	writeMigration(t, dir, "abc_bad_version.up.sql", "")
+
+	files, err := plannedMigrations(dir, 0, true, 0)
+	if err != nil {
+		t.Fatalf("plannedMigrations: %v", err)
+	}
+	if len(files) != 1 || files[0].version != 1 || files[0].name != "init" {
+		t.Fatalf("plannedMigrations() = %+v, want just {1 init}", files)
+	}
+}
+
+// sqliteHandle is one in-memory, shared-cache sqlite database usable from
+// multiple independent *sql.DB handles in the same process. Each call to
+// Up/Down/Version closes the *sql.DB it's given (golang-migrate's Close
+// closes the database driver it was handed), so tests need a fresh handle
+// per call via conn() while an anchor handle keeps the shared-cache
+// database alive (and queryable) for the rest of the test.
+type sqliteHandle struct {
+	t     *testing.T
+	dsn   string
+	conn0 *sql.DB // anchor: keeps the shared-cache db alive; never passed to migrate
+}
+
+// openSQLite returns a sqliteHandle backed by a fresh in-memory database.
+func openSQLite(t *testing.T) *sqliteHandle {
+	t.Helper()
+	dsn := "file::memory:?cache=shared&db=" + t.Name()
+	anchor, err := sql.Open("sqlite3", dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	anchor.SetMaxOpenConns(1)
+	t.Cleanup(func() { anchor.Close() })
+	// Force a real connection now: sql.Open is lazy, and an in-memory
+	// shared-cache database is destroyed once zero real connections are
+	// open against it, which would otherwise race against migrate closing
+	// the *sql.DB it's handed via conn().
+	if err := anchor.Ping(); err != nil {
+		t.Fatal(err)
+	}
+	return &sqliteHandle{t: t, dsn: dsn, conn0: anchor}
+}
+
+// conn opens a new *sql.DB handle onto h's shared-cache database, meant to
+// be handed to Up/Down/Version/etc., which will close it internally.
+func (h *sqliteHandle) conn() *sql.DB {
+	h.t.Helper()
+	db, err := sql.Open("sqlite3", h.dsn)
+	if err != nil {
+		h.t.Fatal(err)
+	}
+	db.SetMaxOpenConns(1)
+	return db
+}
+
+func tableExists(t *testing.T, h *sqliteHandle, name string) bool {
+	t.Helper()
+	var got string
+	err := h.conn0.QueryRow("SELECT name FROM sqlite_master WHERE type='table' AND name=?", name).Scan(&got)
+	if err == sql.ErrNoRows {
+		return false
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	return true
+}
+
+func TestUpThenDown(t *testing.T) {
+	dir := t.TempDir()
+	writeMigration(t, dir, "0001_widgets.up.sql", "CREATE TABLE widgets (id INTEGER PRIMARY KEY);")
+	writeMigration(t, dir, "0001_widgets.down.sql", "DROP TABLE widgets;")
+
+	db := openSQLite(t)
+
+	if err := Up(db.conn(), "sqlite", dir, 0, false, discardLogger()); err != nil {
+		t.Fatalf("Up: %v", err)
+	}
+	if !tableExists(t, db, "widgets") {
+		t.Fatal("Up: widgets table was not created")
+	}
+
+	if err := Down(db.conn(), "sqlite", dir, 0, false, discardLogger()); err != nil {
+		t.Fatalf("Down: %v", err)
+	}
+	if tableExists(t, db, "widgets") {
+		t.Fatal("Down: widgets table was not dropped")
+	}
+}
+
+func TestUpDryRunDoesNotApply(t *testing.T) {
+	dir := t.TempDir()
+	writeMigration(t, dir, "0001_widgets.up.sql", "CREATE TABLE widgets (id INTEGER PRIMARY KEY);")
+	writeMigration(t, dir, "0001_widgets.down.sql", "DROP TABLE widgets;")
+
+	db := openSQLite(t)
+
+	if err := Up(db.conn(), "sqlite", dir, 0, true, discardLogger()); err != nil {
+		t.Fatalf("Up dry-run: %v", err)
+	}
+	if tableExists(t, db, "widgets") {
+		t.Fatal("Up with dryRun=true applied the migration instead of only logging it")
+	}
+}
+
+func TestUpStepsLimitsHowManyApply(t *testing.T) {
+	dir := t.TempDir()
+	writeMigration(t, dir, "0001_widgets.up.sql", "CREATE TABLE widgets (id INTEGER PRIMARY KEY);")
+	writeMigration(t, dir, "0001_widgets.down.sql", "DROP TABLE widgets;")
+	writeMigration(t, dir, "0002_gizmos.up.sql", "CREATE TABLE gizmos (id INTEGER PRIMARY KEY);")
+	writeMigration(t, dir, "0002_gizmos.down.sql", "DROP TABLE gizmos;")
+
+	db := openSQLite(t)
+
+	if err := Up(db.conn(), "sqlite", dir, 1, false, discardLogger()); err != nil {
+		t.Fatalf("Up(steps=1): %v", err)
+	}
+	if !tableExists(t, db, "widgets") {
+		t.Fatal("Up(steps=1) should have applied the first migration")
+	}
+	if tableExists(t, db, "gizmos") {
+		t.Fatal("Up(steps=1) should not have applied the second migration")
+	}
+}
+
+func TestUpUnsupportedDriver(t *testing.T) {
+	dir := t.TempDir()
+	db := openSQLite(t)
+
+	err := Up(db.conn(), "mysql", dir, 0, false, discardLogger())
+	if err == nil {
+		t.Fatal("Up: want error for an unsupported driver, got nil")
+	}
+}
+
+func TestVersionBeforeAnyMigration(t *testing.T) {
+	dir := t.TempDir()
+	writeMigration(t, dir, "0001_widgets.up.sql", "CREATE TABLE widgets (id INTEGER PRIMARY KEY);")
+	writeMigration(t, dir, "0001_widgets.down.sql", "DROP TABLE widgets;")
+
+	db := openSQLite(t)
+
+	version, dirty, err := Version(db.conn(), "sqlite", dir)
+	if err != nil {
+		t.Fatalf("Version: %v", err)
+	}
+	if version != 0 || dirty {
+		t.Fatalf("Version() = (%d, %v), want (0, false) before any migration has run", version, dirty)
+	}
+}
+
+func TestVersionAfterUp(t *testing.T) {
+	dir := t.TempDir()
+	writeMigration(t, dir, "0001_widgets.up.sql", "CREATE TABLE widgets (id INTEGER PRIMARY KEY);")
+	writeMigration(t, dir, "0001_widgets.down.sql", "DROP TABLE widgets;")
+
+	db := openSQLite(t)
+	if err := Up(db.conn(), "sqlite", dir, 0, false, discardLogger()); err != nil {
+		t.Fatalf("Up: %v", err)
+	}
+
+	version, dirty, err := Version(db.conn(), "sqlite", dir)
+	if err != nil {
+		t.Fatalf("Version: %v", err)
+	}
+	if version != 1 || dirty {
+		t.Fatalf("Version() = (%d, %v), want (1, false)", version, dirty)
+	}
+}
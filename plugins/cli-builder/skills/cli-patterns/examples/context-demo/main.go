@@ -0,0 +1,91 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/vanman2024/cli-builder/plugins/cli-builder/skills/cli-patterns/examples/context-demo/internal/appctx"
+	"github.com/vanman2024/cli-builder/plugins/cli-builder/skills/cli-patterns/examples/context-demo/internal/boot"
+	"github.com/vanman2024/cli-builder/plugins/cli-builder/skills/cli-patterns/examples/context-demo/internal/command"
+	"github.com/vanman2024/cli-builder/plugins/cli-builder/skills/cli-patterns/examples/context-demo/internal/config"
+	"github.com/vanman2024/cli-builder/plugins/cli-builder/skills/cli-patterns/examples/context-demo/internal/db"
+	"github.com/vanman2024/cli-builder/plugins/cli-builder/skills/cli-patterns/examples/context-demo/internal/logger"
+)
+
+func main() {
+	// deps starts zero-valued: main's Before fills it in once the boot
+	// tasks have run, and every command built by command.Commands below
+	// closes over this same pointer.
+	deps := &appctx.AppContext{}
+
+	app := &cli.App{
+		Name:  "context-demo",
+		Usage: "Demonstration of context and state management",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:    config.Flags.Verbose,
+				Aliases: []string{"v"},
+				Usage:   "Enable verbose output",
+			},
+			&cli.StringFlag{
+				Name:    config.Flags.ConfigPath,
+				Aliases: []string{"c"},
+				Usage:   "Path to config file",
+				Value:   "config.yaml",
+			},
+			&cli.StringFlag{
+				Name:  config.Flags.Host,
+				Usage: fmt.Sprintf("Database host (env %s)", config.Env.Host),
+			},
+			&cli.IntFlag{
+				Name:  config.Flags.Port,
+				Usage: fmt.Sprintf("Database port (env %s)", config.Env.Port),
+			},
+			&cli.StringFlag{
+				Name:  config.Flags.Database,
+				Usage: fmt.Sprintf("Database name (env %s)", config.Env.Database),
+			},
+			&cli.StringFlag{
+				Name:  config.Flags.Driver,
+				Usage: "Database driver (postgres or sqlite)",
+			},
+		},
+
+		// Boot the app's shared resources: config resolves first, then
+		// the logger and DB connection run concurrently off of it.
+		Before: func(c *cli.Context) error {
+			configTask := config.NewTask(c)
+			loggerTask := logger.NewTask(configTask)
+			dbTask := db.NewTask(configTask)
+
+			booter := boot.NewBooter()
+			booter.Add(configTask)
+			booter.Add(loggerTask, configTask.String())
+			booter.Add(dbTask, configTask.String())
+
+			if err := booter.Boot(c.Context); err != nil {
+				return fmt.Errorf("booting context-demo: %w", err)
+			}
+
+			deps.Bind(booter, configTask, loggerTask, dbTask)
+			deps.Logger().Debug("booted", "host", deps.Config().Host, "database", deps.Config().Database)
+
+			return nil
+		},
+
+		// Tear tasks down in reverse boot order, closing the DB even on
+		// a command error.
+		After: func(c *cli.Context) error {
+			return deps.Shutdown()
+		},
+
+		Commands: command.Commands(deps),
+	}
+
+	if err := app.Run(os.Args); err != nil {
+		log.Fatal(err)
+	}
+}
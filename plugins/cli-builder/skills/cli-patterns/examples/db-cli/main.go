@@ -6,51 +6,61 @@ import (
 	"os"
 
 	"github.com/urfave/cli/v2"
+
+	"github.com/vanman2024/cli-builder/internal/cliapp"
+	"github.com/vanman2024/cli-builder/internal/clilog"
+	"github.com/vanman2024/cli-builder/internal/completion"
+	"github.com/vanman2024/cli-builder/internal/prompt"
+	"github.com/vanman2024/cli-builder/internal/safety"
 )
 
+func init() {
+	completion.Register("migrate", "direction", []string{"up", "down"})
+	safety.MarkDestructive("rollback", safety.Rule{})
+	safety.MarkDestructive("restore", safety.Rule{})
+	safety.MarkDestructive("vacuum", safety.Rule{})
+}
+
 func main() {
-	app := &cli.App{
+	app := cliapp.New(&cli.App{
 		Name:  "dbctl",
 		Usage: "Database management CLI tool",
 		Flags: []cli.Flag{
 			&cli.StringFlag{
-				Name:     "connection",
-				Aliases:  []string{"conn"},
-				Usage:    "Database connection string",
-				EnvVars:  []string{"DATABASE_URL"},
-				Required: true,
+				Name:    "connection",
+				Aliases: []string{"conn"},
+				Usage:   "Database connection string",
+				EnvVars: []string{"DATABASE_URL"},
 			},
 			&cli.BoolFlag{
 				Name:    "verbose",
 				Aliases: []string{"v"},
 				Usage:   "Enable verbose output",
 			},
+
+			prompt.NoPromptFlag,
 		},
 
 		Before: func(c *cli.Context) error {
-			conn := c.String("connection")
-			verbose := c.Bool("verbose")
+			clilog.Logger(c).Debug("validating database connection")
 
-			if verbose {
-				fmt.Println("🔗 Validating database connection...")
+			// Falls back to an interactive, masked prompt on a TTY instead
+			// of hard-failing; --no-prompt keeps the old behavior for CI.
+			conn, err := prompt.Required(c, "connection", prompt.Option{Mask: true})
+			if err != nil {
+				return err
 			}
-
-			// Validate connection string
-			if conn == "" {
-				return fmt.Errorf("database connection string required")
+			if err := c.Set("connection", conn); err != nil {
+				return err
 			}
 
-			if verbose {
-				fmt.Println("✅ Connection string validated")
-			}
+			clilog.Logger(c).Debug("connection string validated")
 
 			return nil
 		},
 
 		After: func(c *cli.Context) error {
-			if c.Bool("verbose") {
-				fmt.Println("🔚 Closing database connections...")
-			}
+			clilog.Logger(c).Debug("closing database connections")
 			return nil
 		},
 
@@ -175,7 +185,13 @@ func main() {
 				},
 			},
 		},
-	}
+	})
+
+	app.EnableConfig(cliapp.ConfigOptions{AppName: "dbctl"})
+	app.InstallCompletion()
+	app.UseSafety()
+	app.EnablePlugins("dbctl")
+	clilog.Install(app.App)
 
 	if err := app.Run(os.Args); err != nil {
 		log.Fatal(err)
@@ -6,6 +6,11 @@ import (
 	"os"
 
 	"github.com/urfave/cli/v2"
+
+	"github.com/vanman2024/cli-builder/internal/cliapp"
+	"github.com/vanman2024/cli-builder/internal/clilog"
+	"github.com/vanman2024/cli-builder/internal/completion"
+	"github.com/vanman2024/cli-builder/internal/safety"
 )
 
 type DeployContext struct {
@@ -14,17 +19,24 @@ type DeployContext struct {
 	Verbose     bool
 }
 
+func init() {
+	completion.Register("deploy", "env", []string{"dev", "staging", "production"})
+	safety.MarkDestructive("deploy", safety.Rule{
+		ConfirmFunc: func(c *cli.Context) string { return c.String("env") },
+	})
+	safety.MarkDestructive("rollback", safety.Rule{})
+}
+
 func main() {
-	app := &cli.App{
+	app := cliapp.New(&cli.App{
 		Name:  "deploy",
 		Usage: "Deployment automation CLI",
 		Flags: []cli.Flag{
 			&cli.StringFlag{
-				Name:     "env",
-				Aliases:  []string{"e"},
-				Usage:    "Target environment",
-				EnvVars:  []string{"DEPLOY_ENV"},
-				Required: true,
+				Name:    "env",
+				Aliases: []string{"e"},
+				Usage:   "Target environment",
+				EnvVars: []string{"DEPLOY_ENV"},
 			},
 			&cli.StringFlag{
 				Name:    "region",
@@ -44,9 +56,7 @@ func main() {
 			region := c.String("region")
 			verbose := c.Bool("verbose")
 
-			if verbose {
-				fmt.Println("🔧 Setting up deployment context...")
-			}
+			clilog.Logger(c).Debug("setting up deployment context")
 
 			// Validate environment
 			validEnvs := []string{"dev", "staging", "production"}
@@ -69,10 +79,7 @@ func main() {
 			}
 			c.App.Metadata["ctx"] = ctx
 
-			if verbose {
-				fmt.Printf("Environment: %s\n", env)
-				fmt.Printf("Region: %s\n", region)
-			}
+			clilog.Logger(c).Debug("deployment context resolved", "environment", env, "region", region)
 
 			return nil
 		},
@@ -115,24 +122,14 @@ func main() {
 				Name:     "deploy",
 				Category: "Deploy",
 				Usage:    "Deploy application",
-				Flags: []cli.Flag{
-					&cli.BoolFlag{
-						Name:  "auto-approve",
-						Usage: "Skip confirmation prompt",
-					},
-				},
 				Action: func(c *cli.Context) error {
 					ctx := c.App.Metadata["ctx"].(*DeployContext)
-					autoApprove := c.Bool("auto-approve")
 
+					// Destructive: safety.Wrap (installed by UseSafety)
+					// already handled --dry-run and the confirmation prompt
+					// (which requires typing the resolved --env value)
+					// before this Action ever runs.
 					fmt.Printf("Deploying to %s in %s...\n", ctx.Environment, ctx.AWSRegion)
-
-					if !autoApprove {
-						fmt.Print("Continue? (y/n): ")
-						// In real app: read user input
-						fmt.Println("y")
-					}
-
 					fmt.Println("Deployment started...")
 
 					return nil
@@ -184,7 +181,13 @@ func main() {
 				},
 			},
 		},
-	}
+	})
+
+	app.EnableConfig(cliapp.ConfigOptions{AppName: "deploy"})
+	app.InstallCompletion()
+	app.UseSafety()
+	app.EnablePlugins("deploy")
+	clilog.Install(app.App)
 
 	if err := app.Run(os.Args); err != nil {
 		log.Fatal(err)
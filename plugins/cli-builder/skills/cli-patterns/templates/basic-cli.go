@@ -6,10 +6,12 @@ import (
 	"os"
 
 	"github.com/urfave/cli/v2"
+
+	"github.com/vanman2024/cli-builder/internal/cliapp"
 )
 
 func main() {
-	app := &cli.App{
+	app := cliapp.New(&cli.App{
 		Name:    "myapp",
 		Usage:   "A simple CLI application",
 		Version: "0.1.0",
@@ -20,23 +22,12 @@ func main() {
 				Usage:   "Enable verbose output",
 				EnvVars: []string{"VERBOSE"},
 			},
-			&cli.StringFlag{
-				Name:    "config",
-				Aliases: []string{"c"},
-				Usage:   "Path to config file",
-				EnvVars: []string{"CONFIG_PATH"},
-			},
 		},
 		Action: func(c *cli.Context) error {
 			verbose := c.Bool("verbose")
-			config := c.String("config")
 
 			if verbose {
-				fmt.Println("Verbose mode enabled")
-			}
-
-			if config != "" {
-				fmt.Printf("Using config: %s\n", config)
+				fmt.Printf("Verbose mode enabled (source: %s)\n", cliapp.Source(c, "verbose"))
 			}
 
 			// Your application logic here
@@ -44,7 +35,13 @@ func main() {
 
 			return nil
 		},
-	}
+	})
+
+	// Looks for myapp.{yaml,yml,toml,json} in ./, $XDG_CONFIG_HOME/myapp/,
+	// and /etc/myapp/, overridable with --config; adds a `config dump`
+	// subcommand and backs the --config flag itself.
+	app.EnableConfig(cliapp.ConfigOptions{AppName: "myapp"})
+	app.InstallCompletion()
 
 	if err := app.Run(os.Args); err != nil {
 		log.Fatal(err)
@@ -7,6 +7,8 @@ import (
 	"time"
 
 	"github.com/urfave/cli/v2"
+
+	"github.com/vanman2024/cli-builder/internal/prompt"
 )
 
 func main() {
@@ -81,12 +83,12 @@ func main() {
 				Usage: "Priority values",
 			},
 
-			// Required flag
+			// Required flag (validated in Before so it can fall back to a
+			// prompt instead of hard-failing before Before even runs)
 			&cli.StringFlag{
-				Name:     "token",
-				Usage:    "API token (required)",
-				Required: true,
-				EnvVars:  []string{"API_TOKEN"},
+				Name:    "token",
+				Usage:   "API token (required)",
+				EnvVars: []string{"API_TOKEN"},
 			},
 
 			// Flag with default from env
@@ -104,6 +106,17 @@ func main() {
 				Usage:  "Secret value",
 				Hidden: true,
 			},
+
+			prompt.NoPromptFlag,
+		},
+		Before: func(c *cli.Context) error {
+			// Required flags fall back to an interactive prompt when stdin
+			// is a TTY, instead of failing before Before even runs.
+			token, err := prompt.Required(c, "token", prompt.Option{Mask: true})
+			if err != nil {
+				return err
+			}
+			return c.Set("token", token)
 		},
 		Action: func(c *cli.Context) error {
 			// String flag
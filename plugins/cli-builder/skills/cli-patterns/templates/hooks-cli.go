@@ -1,11 +1,12 @@
 package main
 
 import (
-	"fmt"
 	"log"
 	"os"
 
 	"github.com/urfave/cli/v2"
+
+	"github.com/vanman2024/cli-builder/internal/clilog"
 )
 
 func main() {
@@ -15,17 +16,15 @@ func main() {
 
 		// Global Before hook - runs before any command
 		Before: func(c *cli.Context) error {
-			fmt.Println("🚀 [GLOBAL BEFORE] Initializing application...")
-			fmt.Println("   - Loading configuration")
-			fmt.Println("   - Setting up connections")
+			clilog.Logger(c).Info("initializing application", "step", "load-configuration")
+			clilog.Logger(c).Info("initializing application", "step", "setup-connections")
 			return nil
 		},
 
 		// Global After hook - runs after any command
 		After: func(c *cli.Context) error {
-			fmt.Println("✅ [GLOBAL AFTER] Cleaning up...")
-			fmt.Println("   - Closing connections")
-			fmt.Println("   - Saving state")
+			clilog.Logger(c).Info("cleaning up", "step", "close-connections")
+			clilog.Logger(c).Info("cleaning up", "step", "save-state")
 			return nil
 		},
 
@@ -36,20 +35,19 @@ func main() {
 
 				// Command-specific Before hook
 				Before: func(c *cli.Context) error {
-					fmt.Println("  [COMMAND BEFORE] Preparing to process...")
-					fmt.Println("    - Validating input")
+					clilog.Logger(c).Debug("preparing to process", "step", "validate-input")
 					return nil
 				},
 
 				// Command action
 				Action: func(c *cli.Context) error {
-					fmt.Println("    [ACTION] Processing data...")
+					clilog.Logger(c).Info("processing data")
 					return nil
 				},
 
 				// Command-specific After hook
 				After: func(c *cli.Context) error {
-					fmt.Println("  [COMMAND AFTER] Processing complete!")
+					clilog.Logger(c).Debug("processing complete")
 					return nil
 				},
 			},
@@ -59,36 +57,29 @@ func main() {
 				Usage: "Validate configuration",
 
 				Before: func(c *cli.Context) error {
-					fmt.Println("  [COMMAND BEFORE] Starting validation...")
+					clilog.Logger(c).Debug("starting validation")
 					return nil
 				},
 
 				Action: func(c *cli.Context) error {
-					fmt.Println("    [ACTION] Validating...")
+					clilog.Logger(c).Info("validating")
 					return nil
 				},
 
 				After: func(c *cli.Context) error {
-					fmt.Println("  [COMMAND AFTER] Validation complete!")
+					clilog.Logger(c).Debug("validation complete")
 					return nil
 				},
 			},
 		},
 	}
 
+	// Adds --log-level/--log-format, injects a *slog.Logger into
+	// App.Metadata, and wraps every command above so it also emits a
+	// "command.completed" event with duration/args/error on exit.
+	clilog.Install(app)
+
 	if err := app.Run(os.Args); err != nil {
 		log.Fatal(err)
 	}
 }
-
-// Example output when running "hooks-demo process":
-// 🚀 [GLOBAL BEFORE] Initializing application...
-//    - Loading configuration
-//    - Setting up connections
-//   [COMMAND BEFORE] Preparing to process...
-//     - Validating input
-//     [ACTION] Processing data...
-//   [COMMAND AFTER] Processing complete!
-// ✅ [GLOBAL AFTER] Cleaning up...
-//    - Closing connections
-//    - Saving state
@@ -1,38 +1,123 @@
 package main
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
 	"fmt"
+	"io"
 	"log"
 	"net/http"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/urfave/cli/v2"
+
+	"github.com/vanman2024/cli-builder/internal/apiclient"
+	"github.com/vanman2024/cli-builder/internal/cliapp"
+	"github.com/vanman2024/cli-builder/internal/output"
+	"github.com/vanman2024/cli-builder/internal/prompt"
+	"github.com/vanman2024/cli-builder/internal/safety"
 )
 
+func init() {
+	safety.MarkDestructive("delete", safety.Rule{})
+}
+
 type APIContext struct {
-	BaseURL    string
-	Token      string
-	HTTPClient *http.Client
+	BaseURL string
+	Token   string
+
+	client *apiclient.Client
+
+	All    bool
+	Limit  int
+	JQ     string
+	Output output.Format
+}
+
+// Do sends req through the shared retry/rate-limit transport, so every
+// subcommand (get/post/put/delete) handles 5xx, network errors, and 429s
+// the same way instead of each rolling its own http.Client.Do call.
+func (a *APIContext) Do(ctx context.Context, req *http.Request) (*apiclient.Response, error) {
+	req.Header.Set("Authorization", "Bearer "+a.Token)
+	return a.client.Do(ctx, req)
+}
+
+// Get issues endpoint as a GET and, when a.All is set, follows the
+// response's rel="next" Link header to fetch subsequent pages too,
+// concatenating each page's JSON array into one result. Pagination only
+// applies when a page's body is itself a JSON array; any other body (a
+// single object, typically) is returned untouched. Pagination stops once
+// a.Limit items have been collected, if a.Limit is positive.
+func (a *APIContext) Get(ctx context.Context, endpoint string, headers []string) ([]byte, error) {
+	url := a.BaseURL + endpoint
+	items := []any{}
+
+	for url != "" {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return nil, err
+		}
+		for _, h := range headers {
+			k, v, ok := strings.Cut(h, ":")
+			if !ok {
+				return nil, fmt.Errorf("invalid --header %q, want key:value", h)
+			}
+			req.Header.Set(strings.TrimSpace(k), strings.TrimSpace(v))
+		}
+
+		resp, err := a.Do(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode >= 400 {
+			return nil, fmt.Errorf("GET %s: %s", url, http.StatusText(resp.StatusCode))
+		}
+
+		page, isArray, err := decodeArray(resp.Body)
+		if err != nil {
+			return nil, err
+		}
+		if !isArray {
+			return resp.Body, nil
+		}
+
+		items = append(items, page...)
+		if a.Limit > 0 && len(items) >= a.Limit {
+			items = items[:a.Limit]
+			break
+		}
+
+		if !a.All {
+			break
+		}
+		next, ok := apiclient.NextPageURL(resp.Header)
+		if !ok {
+			break
+		}
+		url = next
+	}
+
+	return json.Marshal(items)
 }
 
 func main() {
-	app := &cli.App{
+	app := cliapp.New(&cli.App{
 		Name:  "api",
 		Usage: "REST API client CLI",
 		Flags: []cli.Flag{
 			&cli.StringFlag{
-				Name:     "url",
-				Usage:    "API base URL",
-				EnvVars:  []string{"API_URL"},
-				Required: true,
+				Name:    "url",
+				Usage:   "API base URL",
+				EnvVars: []string{"API_URL"},
 			},
 			&cli.StringFlag{
-				Name:     "token",
-				Aliases:  []string{"t"},
-				Usage:    "Authentication token",
-				EnvVars:  []string{"API_TOKEN"},
-				Required: true,
+				Name:    "token",
+				Aliases: []string{"t"},
+				Usage:   "Authentication token",
+				EnvVars: []string{"API_TOKEN"},
 			},
 			&cli.DurationFlag{
 				Name:    "timeout",
@@ -40,25 +125,64 @@ func main() {
 				Value:   30 * time.Second,
 				EnvVars: []string{"API_TIMEOUT"},
 			},
+			&cli.IntFlag{
+				Name:  "max-retries",
+				Usage: "retries on a network error or 5xx/429 response",
+				Value: 3,
+			},
+			&cli.DurationFlag{
+				Name:  "retry-base-delay",
+				Usage: "base delay for exponential backoff between retries",
+				Value: 250 * time.Millisecond,
+			},
+			&cli.BoolFlag{
+				Name:  "all",
+				Usage: "follow Link: rel=\"next\" pagination and fetch every page",
+			},
+			&cli.IntFlag{
+				Name:  "limit",
+				Usage: "stop paginating once this many items have been fetched",
+			},
+			&cli.StringFlag{
+				Name:  "jq",
+				Usage: "filter the response through a jq expression before formatting",
+			},
+			&cli.StringFlag{
+				Name:  "output",
+				Usage: "output format: json, yaml, or table",
+				Value: "json",
+			},
+
+			prompt.NoPromptFlag,
 		},
 
 		Before: func(c *cli.Context) error {
-			baseURL := c.String("url")
-			token := c.String("token")
-			timeout := c.Duration("timeout")
+			// Required flags fall back to an interactive prompt on a TTY;
+			// --no-prompt (or a non-interactive stdin) keeps the old
+			// hard-fail behavior.
+			baseURL, err := prompt.Required(c, "url", prompt.Option{})
+			if err != nil {
+				return err
+			}
+			token, err := prompt.Required(c, "token", prompt.Option{Mask: true})
+			if err != nil {
+				return err
+			}
 
 			fmt.Println("🔐 Authenticating with API...")
 
-			// Create HTTP client
-			client := &http.Client{
-				Timeout: timeout,
-			}
-
-			// Store context
 			ctx := &APIContext{
-				BaseURL:    baseURL,
-				Token:      token,
-				HTTPClient: client,
+				BaseURL: baseURL,
+				Token:   token,
+				client: &apiclient.Client{
+					HTTP:           &http.Client{Timeout: c.Duration("timeout")},
+					MaxRetries:     c.Int("max-retries"),
+					RetryBaseDelay: c.Duration("retry-base-delay"),
+				},
+				All:    c.Bool("all"),
+				Limit:  c.Int("limit"),
+				JQ:     c.String("jq"),
+				Output: output.Format(c.String("output")),
 			}
 			c.App.Metadata["ctx"] = ctx
 
@@ -86,16 +210,11 @@ func main() {
 						return fmt.Errorf("endpoint required")
 					}
 
-					endpoint := c.Args().Get(0)
-					url := fmt.Sprintf("%s%s", ctx.BaseURL, endpoint)
-
-					fmt.Printf("GET %s\n", url)
-					fmt.Printf("Authorization: Bearer %s\n", maskToken(ctx.Token))
-
-					// In real app: make HTTP request
-					fmt.Println("Response: 200 OK")
-
-					return nil
+					body, err := ctx.Get(c.Context, c.Args().Get(0), c.StringSlice("header"))
+					if err != nil {
+						return err
+					}
+					return printResponse(ctx, body)
 				},
 			},
 
@@ -120,16 +239,23 @@ func main() {
 
 					endpoint := c.Args().Get(0)
 					data := c.Args().Get(1)
-					url := fmt.Sprintf("%s%s", ctx.BaseURL, endpoint)
-					contentType := c.String("content-type")
-
-					fmt.Printf("POST %s\n", url)
-					fmt.Printf("Content-Type: %s\n", contentType)
-					fmt.Printf("Data: %s\n", data)
-
-					// In real app: make HTTP POST request
+					req, err := http.NewRequestWithContext(c.Context, http.MethodPost, ctx.BaseURL+endpoint, bytes.NewReader([]byte(data)))
+					if err != nil {
+						return err
+					}
+					req.GetBody = func() (io.ReadCloser, error) {
+						return io.NopCloser(bytes.NewReader([]byte(data))), nil
+					}
+					req.Header.Set("Content-Type", c.String("content-type"))
 
-					return nil
+					resp, err := ctx.Do(c.Context, req)
+					if err != nil {
+						return err
+					}
+					if resp.StatusCode >= 400 {
+						return fmt.Errorf("POST %s: %s", endpoint, http.StatusText(resp.StatusCode))
+					}
+					return printResponse(ctx, resp.Body)
 				},
 			},
 
@@ -146,12 +272,22 @@ func main() {
 
 					endpoint := c.Args().Get(0)
 					data := c.Args().Get(1)
-					url := fmt.Sprintf("%s%s", ctx.BaseURL, endpoint)
-
-					fmt.Printf("PUT %s\n", url)
-					fmt.Printf("Data: %s\n", data)
+					req, err := http.NewRequestWithContext(c.Context, http.MethodPut, ctx.BaseURL+endpoint, bytes.NewReader([]byte(data)))
+					if err != nil {
+						return err
+					}
+					req.GetBody = func() (io.ReadCloser, error) {
+						return io.NopCloser(bytes.NewReader([]byte(data))), nil
+					}
 
-					return nil
+					resp, err := ctx.Do(c.Context, req)
+					if err != nil {
+						return err
+					}
+					if resp.StatusCode >= 400 {
+						return fmt.Errorf("PUT %s: %s", endpoint, http.StatusText(resp.StatusCode))
+					}
+					return printResponse(ctx, resp.Body)
 				},
 			},
 
@@ -167,10 +303,18 @@ func main() {
 					}
 
 					endpoint := c.Args().Get(0)
-					url := fmt.Sprintf("%s%s", ctx.BaseURL, endpoint)
-
-					fmt.Printf("DELETE %s\n", url)
+					req, err := http.NewRequestWithContext(c.Context, http.MethodDelete, ctx.BaseURL+endpoint, nil)
+					if err != nil {
+						return err
+					}
 
+					resp, err := ctx.Do(c.Context, req)
+					if err != nil {
+						return err
+					}
+					if resp.StatusCode >= 400 {
+						return fmt.Errorf("DELETE %s: %s", endpoint, http.StatusText(resp.StatusCode))
+					}
 					return nil
 				},
 			},
@@ -190,13 +334,48 @@ func main() {
 				},
 			},
 		},
-	}
+	})
+
+	app.EnableConfig(cliapp.ConfigOptions{AppName: "api"})
+	app.InstallCompletion()
+	app.UseSafety()
 
 	if err := app.Run(os.Args); err != nil {
 		log.Fatal(err)
 	}
 }
 
+// printResponse filters body through ctx.JQ (if set) and renders it in
+// ctx.Output before printing it to stdout.
+func printResponse(ctx *APIContext, body []byte) error {
+	filtered, err := output.Filter(body, ctx.JQ)
+	if err != nil {
+		return err
+	}
+	rendered, err := output.Render(filtered, ctx.Output)
+	if err != nil {
+		return err
+	}
+	fmt.Println(rendered)
+	return nil
+}
+
+// decodeArray reports whether body's top-level JSON value is an array,
+// returning its elements if so. Only array pages can be concatenated
+// across pagination; Get passes any other body (an object, typically)
+// through untouched instead of forcing it into a one-element array.
+func decodeArray(body []byte) (items []any, isArray bool, err error) {
+	if err := json.Unmarshal(body, &items); err == nil {
+		return items, true, nil
+	}
+
+	var probe any
+	if err := json.Unmarshal(body, &probe); err != nil {
+		return nil, false, fmt.Errorf("decoding response: %w", err)
+	}
+	return nil, false, nil
+}
+
 func maskToken(token string) string {
 	if len(token) < 8 {
 		return "****"
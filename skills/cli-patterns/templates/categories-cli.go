@@ -6,10 +6,12 @@ import (
 	"os"
 
 	"github.com/urfave/cli/v2"
+
+	"github.com/vanman2024/cli-builder/internal/cliapp"
 )
 
 func main() {
-	app := &cli.App{
+	app := cliapp.New(&cli.App{
 		Name:  "myapp",
 		Usage: "CLI tool with categorized commands",
 		Commands: []*cli.Command{
@@ -133,7 +135,9 @@ func main() {
 				},
 			},
 		},
-	}
+	})
+
+	app.InstallCompletion()
 
 	if err := app.Run(os.Args); err != nil {
 		log.Fatal(err)